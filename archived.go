@@ -0,0 +1,122 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ProjectData is the content of a single project as returned by the Sync API, which also works
+// for archived projects.
+type ProjectData struct {
+	Project  Project
+	Sections []Section
+	Tasks    []Task
+	// Comments are the comments on the project and, where the API includes them, on its tasks.
+	Comments []Comment
+}
+
+// GetArchivedProjects fetches the archived projects, which GetProjects and Sync leave out.
+func (c *TodoistClient) GetArchivedProjects() ([]Project, error) {
+	return c.GetArchivedProjectsContext(context.Background())
+}
+
+// GetArchivedProjectsContext is like GetArchivedProjects but uses ctx for the request.
+func (c *TodoistClient) GetArchivedProjectsContext(ctx context.Context) ([]Project, error) {
+	resp, err := sendForm(ctx, c.HTTPClient, fmt.Sprintf("%s/projects/get_archived", c.SyncBaseURL), c.tokenSource(), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var archived []SyncProject
+	if err := parseResponse(resp, &archived); err != nil {
+		return nil, err
+	}
+
+	projects := make([]Project, 0, len(archived))
+	for _, p := range archived {
+		if !p.IsDeleted {
+			projects = append(projects, p.ToProject())
+		}
+	}
+	return projects, nil
+}
+
+// GetProjectData fetches a project with its sections, active tasks and comments. Unlike the
+// REST endpoints it also returns the content of archived projects.
+func (c *TodoistClient) GetProjectData(projectID string) (*ProjectData, error) {
+	return c.GetProjectDataContext(context.Background(), projectID)
+}
+
+// GetProjectDataContext is like GetProjectData but uses ctx for the request.
+func (c *TodoistClient) GetProjectDataContext(ctx context.Context, projectID string) (*ProjectData, error) {
+	form := url.Values{}
+	form.Set("project_id", projectID)
+
+	resp, err := sendForm(ctx, c.HTTPClient, fmt.Sprintf("%s/projects/get_data", c.SyncBaseURL), c.tokenSource(), form)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Project      SyncProject   `json:"project"`
+		Sections     []SyncSection `json:"sections"`
+		Items        []SyncItem    `json:"items"`
+		Notes        []SyncNote    `json:"notes"`
+		ProjectNotes []SyncNote    `json:"project_notes"`
+	}
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	data := &ProjectData{Project: result.Project.ToProject()}
+	for _, s := range result.Sections {
+		if !s.IsDeleted {
+			data.Sections = append(data.Sections, s.ToSection())
+		}
+	}
+	for _, item := range result.Items {
+		if !item.IsDeleted && !item.Checked {
+			data.Tasks = append(data.Tasks, item.ToTask())
+		}
+	}
+	for _, list := range [][]SyncNote{result.ProjectNotes, result.Notes} {
+		for _, n := range list {
+			if !n.IsDeleted {
+				data.Comments = append(data.Comments, n.ToComment())
+			}
+		}
+	}
+	return data, nil
+}
+
+// ArchiveProject archives a project together with its subprojects.
+func (c *TodoistClient) ArchiveProject(id string) (bool, error) {
+	return c.ArchiveProjectContext(context.Background(), id)
+}
+
+// ArchiveProjectContext is like ArchiveProject but uses ctx for the request.
+func (c *TodoistClient) ArchiveProjectContext(ctx context.Context, id string) (bool, error) {
+	return c.projectCommand(ctx, "project_archive", id)
+}
+
+// UnarchiveProject restores an archived project.
+func (c *TodoistClient) UnarchiveProject(id string) (bool, error) {
+	return c.UnarchiveProjectContext(context.Background(), id)
+}
+
+// UnarchiveProjectContext is like UnarchiveProject but uses ctx for the request.
+func (c *TodoistClient) UnarchiveProjectContext(ctx context.Context, id string) (bool, error) {
+	return c.projectCommand(ctx, "project_unarchive", id)
+}
+
+func (c *TodoistClient) projectCommand(ctx context.Context, commandType, id string) (bool, error) {
+	args := struct {
+		ID string `json:"id"`
+	}{id}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand(commandType, args)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
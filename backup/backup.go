@@ -0,0 +1,197 @@
+// Package backup snapshots a Todoist account into a versioned JSON archive and restores an
+// archive into an account, which may be empty or a different one.
+//
+// An archive holds the projects, archived ones included, sections, active and completed tasks,
+// comments with their attachment metadata and personal labels. Attachments are kept as references to the files
+// uploaded to Todoist; their content is not downloaded.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// FormatVersion is the archive format written by Create. Read accepts archives up to this version.
+const FormatVersion = 1
+
+// Archive is a snapshot of an account.
+type Archive struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+	Projects  []todoist.Project `json:"projects"`
+	Sections  []todoist.Section `json:"sections"`
+	// Tasks are the active tasks.
+	Tasks          []todoist.Task    `json:"tasks"`
+	CompletedTasks []CompletedTask   `json:"completed_tasks"`
+	Comments       []todoist.Comment `json:"comments"`
+	Labels         []todoist.Label   `json:"labels"`
+	// ArchivedProjects are the IDs of the projects that were archived. Their sections, tasks
+	// and comments are part of the other lists.
+	ArchivedProjects []string `json:"archived_projects,omitempty"`
+}
+
+// CompletedTask is a completed task and the time it was completed.
+type CompletedTask struct {
+	todoist.Task
+	CompletedAt string `json:"completed_at"`
+}
+
+// Stage is a step of a backup or restore.
+type Stage string
+
+const (
+	StageProjects       Stage = "projects"
+	StageSections       Stage = "sections"
+	StageTasks          Stage = "tasks"
+	StageCompletedTasks Stage = "completed_tasks"
+	StageComments       Stage = "comments"
+	StageLabels         Stage = "labels"
+)
+
+// Progress reports how far a stage has got. Total is zero while it is not known yet.
+type Progress struct {
+	Stage Stage
+	Done  int
+	Total int
+}
+
+// Options configures a backup.
+type Options struct {
+	// Progress, if set, is called after each stage.
+	Progress func(Progress)
+	// Now returns the creation time of the archive. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Create snapshots the account of client. Active data is read with one full sync and completed
+// tasks, with their comments, are paged through afterwards.
+func Create(client *todoist.TodoistClient, opts Options) (*Archive, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	report := func(stage Stage, n int) {
+		if opts.Progress != nil {
+			opts.Progress(Progress{Stage: stage, Done: n, Total: n})
+		}
+	}
+
+	archive := &Archive{Version: FormatVersion, CreatedAt: now().UTC()}
+	resp, err := client.Sync("",
+		todoist.ResourceProjects, todoist.ResourceSections, todoist.ResourceItems,
+		todoist.ResourceNotes, todoist.ResourceProjectNotes, todoist.ResourceLabels)
+	if err != nil {
+		return nil, fmt.Errorf("backup: sync: %w", err)
+	}
+
+	for _, p := range resp.Projects {
+		if !p.IsDeleted {
+			archive.Projects = append(archive.Projects, p.ToProject())
+		}
+	}
+	for _, s := range resp.Sections {
+		if !s.IsDeleted {
+			archive.Sections = append(archive.Sections, s.ToSection())
+		}
+	}
+	for _, item := range resp.Items {
+		if !item.IsDeleted && !item.Checked {
+			archive.Tasks = append(archive.Tasks, item.ToTask())
+		}
+	}
+
+	// Archived projects are not part of the sync and are read one by one.
+	archived, err := client.GetArchivedProjects()
+	if err != nil {
+		return nil, fmt.Errorf("backup: list archived projects: %w", err)
+	}
+	var archivedComments []todoist.Comment
+	for _, p := range archived {
+		data, err := client.GetProjectData(p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("backup: read archived project %q: %w", p.Name, err)
+		}
+		archive.Projects = append(archive.Projects, p)
+		archive.ArchivedProjects = append(archive.ArchivedProjects, p.ID)
+		archive.Sections = append(archive.Sections, data.Sections...)
+		archive.Tasks = append(archive.Tasks, data.Tasks...)
+		archivedComments = append(archivedComments, data.Comments...)
+	}
+	report(StageProjects, len(archive.Projects))
+	report(StageSections, len(archive.Sections))
+	report(StageTasks, len(archive.Tasks))
+
+	for _, l := range resp.Labels {
+		if !l.IsDeleted {
+			archive.Labels = append(archive.Labels, l.ToLabel())
+		}
+	}
+	report(StageLabels, len(archive.Labels))
+
+	completed, err := client.GetAllCompletedTasks(todoist.CompletedTasksParams{WithItems: true, WithNotes: true})
+	if err != nil {
+		return nil, fmt.Errorf("backup: list completed tasks: %w", err)
+	}
+	// A recurring task that was completed before is still active, so the active copy is kept.
+	seen := make(map[string]bool, len(archive.Tasks))
+	for _, t := range archive.Tasks {
+		seen[t.ID] = true
+	}
+	var notes []todoist.SyncNote
+	for _, c := range completed {
+		if seen[c.TaskID] {
+			continue
+		}
+		seen[c.TaskID] = true
+		archive.CompletedTasks = append(archive.CompletedTasks, CompletedTask{Task: c.ToTask(), CompletedAt: c.CompletedAt})
+		notes = append(notes, c.Notes...)
+	}
+	report(StageCompletedTasks, len(archive.CompletedTasks))
+
+	// Comments of completed tasks may also be part of the sync, so they are collected once by ID.
+	seen = make(map[string]bool)
+	for _, list := range [][]todoist.SyncNote{resp.Notes, resp.ProjectNotes, notes} {
+		for _, n := range list {
+			if n.IsDeleted || seen[n.ID] {
+				continue
+			}
+			seen[n.ID] = true
+			archive.Comments = append(archive.Comments, n.ToComment())
+		}
+	}
+	for _, c := range archivedComments {
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			archive.Comments = append(archive.Comments, c)
+		}
+	}
+	report(StageComments, len(archive.Comments))
+	return archive, nil
+}
+
+// Write writes an archive as indented JSON.
+func Write(w io.Writer, archive *Archive) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(archive)
+}
+
+// Read reads an archive written by Write, rejecting archives of an unknown format version.
+func Read(r io.Reader) (*Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+	switch {
+	case archive.Version == 0:
+		return nil, errors.New("backup: not an archive: version is missing")
+	case archive.Version > FormatVersion:
+		return nil, fmt.Errorf("backup: archive version %d is newer than the supported version %d", archive.Version, FormatVersion)
+	}
+	return &archive, nil
+}
@@ -0,0 +1,341 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// RestoreOptions configures a restore.
+type RestoreOptions struct {
+	// Progress, if set, is called after every record that is restored.
+	Progress func(Progress)
+	// KeepAssignees assigns tasks to the users of the archive. Leave it unset when restoring
+	// into a different account, whose collaborators are not the same.
+	KeepAssignees bool
+}
+
+// IDMap maps the IDs of an archive to the IDs of the restored records, by record type.
+type IDMap struct {
+	Projects map[string]string
+	Sections map[string]string
+	Tasks    map[string]string
+	Comments map[string]string
+	Labels   map[string]string
+}
+
+// RestoreResult lists what a restore did.
+type RestoreResult struct {
+	IDs IDMap
+	// Skipped are the IDs of sections, tasks and comments that were not restored because their
+	// project or task is not part of the archive.
+	Skipped []string
+}
+
+// Restore recreates an archive in the account of client and returns the new ID of every
+// record. Labels that already exist by name are reused, the Inbox of the archive is mapped
+// to the Inbox of the account and everything else is created. Completed tasks are created
+// and then completed, comments are restored in the order they were posted and archived projects
+// are archived again once their content is restored.
+//
+// Restoring into an account that already holds the archive duplicates its records, and so does
+// retrying a restore that failed part way; the IDs returned with the error show which records
+// already exist.
+func Restore(client *todoist.TodoistClient, archive *Archive, opts RestoreOptions) (*RestoreResult, error) {
+	r := &restorer{
+		client: client,
+		opts:   opts,
+		result: &RestoreResult{IDs: IDMap{
+			Projects: make(map[string]string),
+			Sections: make(map[string]string),
+			Tasks:    make(map[string]string),
+			Comments: make(map[string]string),
+			Labels:   make(map[string]string),
+		}},
+	}
+	for _, step := range []func(*Archive) error{r.labels, r.projects, r.sections, r.tasks, r.comments, r.archive} {
+		if err := step(archive); err != nil {
+			return r.result, err
+		}
+	}
+	return r.result, nil
+}
+
+type restorer struct {
+	client *todoist.TodoistClient
+	opts   RestoreOptions
+	result *RestoreResult
+}
+
+func (r *restorer) progress(stage Stage, done, total int) {
+	if r.opts.Progress != nil {
+		r.opts.Progress(Progress{Stage: stage, Done: done, Total: total})
+	}
+}
+
+func (r *restorer) labels(archive *Archive) error {
+	existing, err := r.client.GetLabels()
+	if err != nil {
+		return fmt.Errorf("backup: list labels: %w", err)
+	}
+	byName := make(map[string]string, len(existing))
+	for _, l := range existing {
+		byName[l.Name] = l.ID
+	}
+
+	for i, l := range archive.Labels {
+		id, ok := byName[l.Name]
+		if !ok {
+			label, err := r.client.CreateLabel(todoist.LabelParams{Name: l.Name, Color: l.Color, Order: l.Order, IsFavorite: l.IsFavorite})
+			if err != nil {
+				return fmt.Errorf("backup: create label %q: %w", l.Name, err)
+			}
+			id = label.ID
+		}
+		r.result.IDs.Labels[l.ID] = id
+		r.progress(StageLabels, i+1, len(archive.Labels))
+	}
+	return nil
+}
+
+// projects creates projects parents first, so every parent ID can be mapped.
+func (r *restorer) projects(archive *Archive) error {
+	existing, err := r.client.GetProjects()
+	if err != nil {
+		return fmt.Errorf("backup: list projects: %w", err)
+	}
+	inbox := ""
+	for _, p := range existing {
+		if p.IsInboxProject {
+			inbox = p.ID
+		}
+	}
+
+	ids := make(map[string]bool, len(archive.Projects))
+	for _, p := range archive.Projects {
+		ids[p.ID] = true
+	}
+	children := make(map[string][]todoist.Project)
+	for _, p := range archive.Projects {
+		parent := ""
+		if p.ParentID != nil && ids[*p.ParentID] {
+			parent = *p.ParentID
+		}
+		children[parent] = append(children[parent], p)
+	}
+
+	done := 0
+	var create func(parent string) error
+	create = func(parent string) error {
+		list := children[parent]
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+		for _, p := range list {
+			if p.IsInboxProject && inbox != "" {
+				r.result.IDs.Projects[p.ID] = inbox
+			} else {
+				project, err := r.client.CreateProject(todoist.ProjectParams{
+					Name:       p.Name,
+					ParentID:   r.result.IDs.Projects[parent],
+					Color:      p.Color,
+					IsFavorite: p.IsFavorite,
+					ViewStyle:  p.ViewStyle,
+				})
+				if err != nil {
+					return fmt.Errorf("backup: create project %q: %w", p.Name, err)
+				}
+				r.result.IDs.Projects[p.ID] = project.ID
+			}
+			done++
+			r.progress(StageProjects, done, len(archive.Projects))
+			if err := create(p.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return create("")
+}
+
+func (r *restorer) sections(archive *Archive) error {
+	sections := append([]todoist.Section(nil), archive.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Order < sections[j].Order })
+
+	for i, s := range sections {
+		projectID, ok := r.result.IDs.Projects[s.ProjectID]
+		if !ok {
+			r.result.Skipped = append(r.result.Skipped, s.ID)
+			continue
+		}
+		section, err := r.client.CreateSection(todoist.SectionParams{ProjectID: projectID, Name: s.Name, Order: s.Order})
+		if err != nil {
+			return fmt.Errorf("backup: create section %q: %w", s.Name, err)
+		}
+		r.result.IDs.Sections[s.ID] = section.ID
+		r.progress(StageSections, i+1, len(sections))
+	}
+	return nil
+}
+
+// tasks creates active and completed tasks together, parents before their subtasks, and then
+// completes the completed ones, subtasks first.
+func (r *restorer) tasks(archive *Archive) error {
+	tasks := append([]todoist.Task(nil), archive.Tasks...)
+	for _, t := range archive.CompletedTasks {
+		task := t.Task
+		task.IsCompleted = true
+		tasks = append(tasks, task)
+	}
+
+	ids := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		ids[t.ID] = true
+	}
+	children := make(map[string][]todoist.Task)
+	for _, t := range tasks {
+		parent := ""
+		if t.ParentID != "" && ids[t.ParentID] {
+			parent = t.ParentID
+		}
+		children[parent] = append(children[parent], t)
+	}
+
+	// Subtasks of a task that is skipped are skipped with it.
+	var skip func(id string)
+	skip = func(id string) {
+		r.result.Skipped = append(r.result.Skipped, id)
+		for _, child := range children[id] {
+			skip(child.ID)
+		}
+	}
+
+	var completed []todoist.Task
+	done := 0
+	var create func(parent string) error
+	create = func(parent string) error {
+		list := children[parent]
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+		for _, t := range list {
+			projectID, ok := r.result.IDs.Projects[t.ProjectID]
+			if !ok {
+				skip(t.ID)
+				continue
+			}
+			task, err := r.client.CreateTask(r.taskParams(t, projectID, r.result.IDs.Tasks[parent]))
+			if err != nil {
+				return fmt.Errorf("backup: create task %q: %w", t.Content, err)
+			}
+			r.result.IDs.Tasks[t.ID] = task.ID
+			if t.IsCompleted {
+				completed = append(completed, t)
+			}
+			done++
+			r.progress(StageTasks, done, len(tasks))
+			if err := create(t.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := create(""); err != nil {
+		return err
+	}
+
+	for i := len(completed) - 1; i >= 0; i-- {
+		t := completed[i]
+		if _, err := r.client.CloseTask(r.result.IDs.Tasks[t.ID]); err != nil {
+			return fmt.Errorf("backup: complete task %q: %w", t.Content, err)
+		}
+		r.progress(StageCompletedTasks, len(completed)-i, len(completed))
+	}
+	return nil
+}
+
+// taskParams returns the parameters recreating a task. The due date of a completed recurring
+// task is restored as a plain date, since completing a recurring task reschedules it.
+func (r *restorer) taskParams(t todoist.Task, projectID, parentID string) todoist.TaskParams {
+	params := todoist.TaskParams{
+		Content:     t.Content,
+		Description: t.Description,
+		ProjectID:   projectID,
+		ParentID:    parentID,
+		Priority:    t.Priority,
+		Labels:      t.Labels,
+	}
+	if parentID == "" {
+		params.SectionID = r.result.IDs.Sections[t.SectionID]
+	}
+	if r.opts.KeepAssignees {
+		params.AssigneeID = t.AssigneeID
+	}
+	if d := t.Duration; d != nil && d.Amount > 0 {
+		params.Duration, params.DurationUnit = d.Amount, d.Unit
+	}
+
+	switch due := t.Due; {
+	case due == nil:
+	case due.IsRecurring && !t.IsCompleted:
+		params.DueString = due.String
+	case due.Datetime != "":
+		if t, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+			params.DueDatetime = t.Format(time.RFC3339)
+		} else if t, err := time.Parse("2006-01-02T15:04:05", due.Datetime); err == nil {
+			// A floating time has no zone, so it is left to Todoist's date parser.
+			params.DueString = t.Format("2006-01-02 15:04")
+		}
+	default:
+		params.DueDate = due.Date
+	}
+	return params
+}
+
+// comments restores comments in the order they were posted, keeping their attachment metadata.
+func (r *restorer) comments(archive *Archive) error {
+	comments := append([]todoist.Comment(nil), archive.Comments...)
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].PostedAt < comments[j].PostedAt })
+
+	for i, c := range comments {
+		params := todoist.CommentParams{Content: c.Content, Attachment: c.Attachment}
+		ok := false
+		if c.TaskID != "" {
+			params.TaskID, ok = r.result.IDs.Tasks[c.TaskID]
+		} else {
+			params.ProjectID, ok = r.result.IDs.Projects[c.ProjectID]
+		}
+		if !ok {
+			r.result.Skipped = append(r.result.Skipped, c.ID)
+			continue
+		}
+
+		comment, err := r.client.CreateComment(params)
+		if err != nil {
+			return fmt.Errorf("backup: create comment %s: %w", c.ID, err)
+		}
+		r.result.IDs.Comments[c.ID] = comment.ID
+		r.progress(StageComments, i+1, len(comments))
+	}
+	return nil
+}
+
+// archive archives the restored projects that were archived. Subprojects of an archived project
+// are archived with it.
+func (r *restorer) archive(archive *Archive) error {
+	archived := make(map[string]bool, len(archive.ArchivedProjects))
+	for _, id := range archive.ArchivedProjects {
+		archived[id] = true
+	}
+	for _, p := range archive.Projects {
+		if !archived[p.ID] || (p.ParentID != nil && archived[*p.ParentID]) {
+			continue
+		}
+		id, ok := r.result.IDs.Projects[p.ID]
+		if !ok {
+			continue
+		}
+		if _, err := r.client.ArchiveProject(id); err != nil {
+			return fmt.Errorf("backup: archive project %q: %w", p.Name, err)
+		}
+	}
+	return nil
+}
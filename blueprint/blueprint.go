@@ -0,0 +1,175 @@
+// Package blueprint captures projects as reusable templates and creates new projects from them.
+//
+// A template is a YAML file holding a project's sections, tasks and subtasks. Due dates are
+// stored as offsets in days from a base date, and any text may contain variables such as
+// {{version}} that are filled in when the template is instantiated:
+//
+//	name: Release {{version}}
+//	sections:
+//	  - name: Preparation
+//	    tasks:
+//	      - content: Freeze {{version}} branch
+//	        priority: P2
+//	        labels: [release]
+//	        due_days: 0
+//	        due_time: "10:00"
+//	        assignee: "{{owner}}"
+//	        subtasks:
+//	          - content: Announce the freeze
+//	            due_days: -1
+//	  - name: Launch
+//	    tasks:
+//	      - content: Publish release notes
+//	        due_days: 7
+//
+// Instantiating with the base date 2026-11-02 makes the freeze due at 10:00 on November 2 and
+// the release notes due on November 9.
+package blueprint
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Template is a project to be created again and again.
+type Template struct {
+	Name      string            `yaml:"name"`
+	Color     todoist.Color     `yaml:"color,omitempty"`
+	ViewStyle todoist.ViewStyle `yaml:"view_style,omitempty"`
+	// Tasks are the tasks outside sections.
+	Tasks    []*Task    `yaml:"tasks,omitempty"`
+	Sections []*Section `yaml:"sections,omitempty"`
+}
+
+// Section is a section of a template and its tasks.
+type Section struct {
+	Name  string  `yaml:"name"`
+	Tasks []*Task `yaml:"tasks,omitempty"`
+}
+
+// Task is a task of a template.
+type Task struct {
+	Content     string `yaml:"content"`
+	Description string `yaml:"description,omitempty"`
+	// Priority is zero for the default priority P4.
+	Priority todoist.Priority `yaml:"priority,omitempty"`
+	Labels   []string         `yaml:"labels,omitempty"`
+	// DueDays is the due date in days after the base date, nil for a task without due date.
+	DueDays *int `yaml:"due_days,omitempty"`
+	// DueTime is the time of day ("15:04") the task is due on its due date, empty for all day.
+	DueTime string `yaml:"due_time,omitempty"`
+	// DueString is a recurring due date such as "every monday", used instead of DueDays.
+	DueString string                `yaml:"due_string,omitempty"`
+	Duration  *todoist.TaskDuration `yaml:"duration,omitempty"`
+	// Assignee is the user ID the task is assigned to, usually a variable.
+	Assignee string  `yaml:"assignee,omitempty"`
+	Subtasks []*Task `yaml:"subtasks,omitempty"`
+}
+
+// Read reads a template written by Write.
+func Read(r io.Reader) (*Template, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var t Template
+	if err := dec.Decode(&t); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("blueprint: empty template")
+		}
+		return nil, fmt.Errorf("blueprint: %w", err)
+	}
+	return &t, nil
+}
+
+// Write writes a template as YAML.
+func Write(w io.Writer, t *Template) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(t); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// variable matches a variable reference such as {{version}} or {{ owner }}.
+var variable = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// Variables returns the names of the variables used by a template, sorted.
+func (t *Template) Variables() []string {
+	seen := make(map[string]bool)
+	t.eachText(func(s *string) {
+		for _, m := range variable.FindAllStringSubmatch(*s, -1) {
+			seen[m[1]] = true
+		}
+	})
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// eachText calls fn with every text of a template that may contain variables.
+func (t *Template) eachText(fn func(*string)) {
+	var tasks func([]*Task)
+	tasks = func(list []*Task) {
+		for _, task := range list {
+			fn(&task.Content)
+			fn(&task.Description)
+			fn(&task.Assignee)
+			for i := range task.Labels {
+				fn(&task.Labels[i])
+			}
+			tasks(task.Subtasks)
+		}
+	}
+
+	fn(&t.Name)
+	tasks(t.Tasks)
+	for _, s := range t.Sections {
+		fn(&s.Name)
+		tasks(s.Tasks)
+	}
+}
+
+// substitute replaces the variables of s with their values, recording the names of variables without value.
+func substitute(s string, vars map[string]string, missing map[string]bool) string {
+	return variable.ReplaceAllStringFunc(s, func(ref string) string {
+		name := variable.FindStringSubmatch(ref)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing[name] = true
+			return ref
+		}
+		return value
+	})
+}
+
+// placeholders replaces the values of vars in s with variable references, longest values first.
+func placeholders(s string, vars map[string]string) string {
+	names := make([]string, 0, len(vars))
+	for name, value := range vars {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(vars[names[i]]) != len(vars[names[j]]) {
+			return len(vars[names[i]]) > len(vars[names[j]])
+		}
+		return names[i] < names[j]
+	})
+
+	pairs := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		pairs = append(pairs, vars[name], "{{"+name+"}}")
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
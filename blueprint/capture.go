@@ -0,0 +1,166 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// CaptureOptions configures Capture.
+type CaptureOptions struct {
+	// BaseDate is the date due offsets are counted from. Defaults to the earliest due date of the
+	// project's tasks.
+	BaseDate time.Time
+	// Variables maps variable names to values that are replaced with the variable in all texts,
+	// such as {"version": "1.4"} turning "Release 1.4" into "Release {{version}}".
+	Variables map[string]string
+	// Assignees keeps the user IDs tasks are assigned to. They are usually replaced by a
+	// variable through Variables.
+	Assignees bool
+	// Location is the timezone of due times that have none. Defaults to time.Local.
+	Location *time.Location
+}
+
+// Capture reads a project's sections and active tasks into a template.
+func Capture(client *todoist.TodoistClient, projectID string, opts CaptureOptions) (*Template, error) {
+	project, err := client.GetProject(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("blueprint: get project: %w", err)
+	}
+	sections, err := client.GetSections(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("blueprint: list sections: %w", err)
+	}
+	tasks, err := client.GetTasks(projectID, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("blueprint: list tasks: %w", err)
+	}
+	return FromProject(*project, sections, tasks, opts), nil
+}
+
+// FromProject builds the template of a project from its sections and tasks. Tasks are ordered as
+// in Todoist, and subtasks whose parent is missing are treated as top-level tasks.
+func FromProject(project todoist.Project, sections []todoist.Section, tasks []todoist.Task, opts CaptureOptions) *Template {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	base := opts.BaseDate
+	if base.IsZero() {
+		base = earliestDue(tasks, loc)
+	}
+	base = date(base.Year(), base.Month(), base.Day())
+
+	ids := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		ids[t.ID] = true
+	}
+	children := make(map[string][]todoist.Task)
+	for _, t := range tasks {
+		parent := "section:" + t.SectionID
+		if t.ParentID != "" && ids[t.ParentID] {
+			parent = t.ParentID
+		}
+		children[parent] = append(children[parent], t)
+	}
+	for _, list := range children {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+	}
+
+	var build func(parent string) []*Task
+	build = func(parent string) []*Task {
+		var list []*Task
+		for _, t := range children[parent] {
+			task := captureTask(t, base, loc, opts.Assignees)
+			task.Subtasks = build(t.ID)
+			list = append(list, task)
+		}
+		return list
+	}
+
+	tmpl := &Template{Name: project.Name, Color: project.Color, ViewStyle: project.ViewStyle, Tasks: build("section:")}
+	sorted := append([]todoist.Section(nil), sections...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+	for _, s := range sorted {
+		tmpl.Sections = append(tmpl.Sections, &Section{Name: s.Name, Tasks: build("section:" + s.ID)})
+	}
+
+	if len(opts.Variables) > 0 {
+		tmpl.eachText(func(s *string) { *s = placeholders(*s, opts.Variables) })
+	}
+	return tmpl
+}
+
+func captureTask(t todoist.Task, base time.Time, loc *time.Location, assignees bool) *Task {
+	task := &Task{
+		Content:     t.Content,
+		Description: t.Description,
+		Labels:      t.Labels,
+		Duration:    t.Duration,
+	}
+	if t.Priority != todoist.PriorityP4 {
+		task.Priority = t.Priority
+	}
+	if assignees {
+		task.Assignee = t.AssigneeID
+	}
+
+	switch due := t.Due; {
+	case due == nil:
+	case due.IsRecurring:
+		task.DueString = due.String
+	default:
+		day, clock, ok := dueDate(due, loc)
+		if !ok {
+			break
+		}
+		days := int(day.Sub(base).Hours() / 24)
+		task.DueDays = &days
+		task.DueTime = clock
+	}
+	return task
+}
+
+// dueDate returns the date of a due date at midnight UTC and its time of day, empty for all-day
+// due dates. Due times with a timezone are read in that timezone, or else in loc.
+func dueDate(due *todoist.TaskDue, loc *time.Location) (time.Time, string, bool) {
+	if due.Datetime == "" {
+		t, err := time.Parse(time.DateOnly, due.Date)
+		return t, "", err == nil
+	}
+
+	t, err := time.Parse(time.RFC3339, due.Datetime)
+	if err == nil {
+		if tz, err := time.LoadLocation(due.Timezone); due.Timezone != "" && err == nil {
+			loc = tz
+		}
+		t = t.In(loc)
+	} else if t, err = time.Parse("2006-01-02T15:04:05", due.Datetime); err != nil {
+		return time.Time{}, "", false
+	}
+	return date(t.Year(), t.Month(), t.Day()), t.Format("15:04"), true
+}
+
+// earliestDue returns the earliest non-recurring due date of tasks, or today if none has one.
+func earliestDue(tasks []todoist.Task, loc *time.Location) time.Time {
+	var earliest time.Time
+	for _, t := range tasks {
+		if t.Due == nil || t.Due.IsRecurring {
+			continue
+		}
+		if day, _, ok := dueDate(t.Due, loc); ok && (earliest.IsZero() || day.Before(earliest)) {
+			earliest = day
+		}
+	}
+	if earliest.IsZero() {
+		return time.Now().In(loc)
+	}
+	return earliest
+}
+
+// date returns midnight UTC of a day, so that days can be counted without daylight saving shifts.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,205 @@
+package blueprint
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/internal/tasktree"
+)
+
+// InstantiateOptions configures Instantiate.
+type InstantiateOptions struct {
+	// ProjectID is an existing project to create the sections and tasks in, for example a shared
+	// project when tasks are assigned. By default a new project is created.
+	ProjectID string
+	// Name is the name of the new project. Defaults to the template name.
+	Name string
+	// BaseDate is the date due offsets are counted from. Defaults to today.
+	BaseDate time.Time
+	// Variables are the values of the template's variables. Every variable used must have one.
+	Variables map[string]string
+	// Location is the timezone of due times. Defaults to time.Local.
+	Location *time.Location
+}
+
+// Result lists what Instantiate created.
+type Result struct {
+	ProjectID string
+	Sections  []todoist.Section
+	Tasks     []todoist.Task
+}
+
+// Instantiate creates a project, or fills an existing one, from a template. Variables are
+// replaced in all texts and due dates are set relative to the base date.
+//
+// The whole template is checked before anything is created; missing variables and invalid tasks
+// are returned as a todoist.ValidationErrors. A failed request ends the instantiation with a
+// partial Result alongside the error; delete its project, or the listed sections and tasks,
+// before trying again.
+func Instantiate(client *todoist.TodoistClient, t *Template, opts InstantiateOptions) (*Result, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	base := opts.BaseDate
+	if base.IsZero() {
+		base = time.Now().In(loc)
+	}
+	base = date(base.Year(), base.Month(), base.Day())
+
+	filled, err := fill(t, opts.Variables)
+	if err != nil {
+		return nil, err
+	}
+	inst := &instance{base: base, loc: loc, result: &Result{ProjectID: opts.ProjectID}}
+	if err := inst.validate(filled); err != nil {
+		return nil, err
+	}
+
+	if opts.ProjectID == "" {
+		name := opts.Name
+		if name == "" {
+			name = filled.Name
+		}
+		project, err := client.CreateProject(todoist.ProjectParams{Name: name, Color: filled.Color, ViewStyle: filled.ViewStyle})
+		if err != nil {
+			return nil, fmt.Errorf("blueprint: create project: %w", err)
+		}
+		inst.result.ProjectID = project.ID
+	}
+
+	tree := &tasktree.Tree[*Task]{
+		Client:    client,
+		ProjectID: inst.result.ProjectID,
+		Params: func(t *Task) todoist.TaskParams {
+			params, _ := inst.taskParams(t)
+			return params
+		},
+		Children: func(t *Task) []*Task { return t.Subtasks },
+		Name:     func(t *Task) string { return fmt.Sprintf("blueprint: task %q", t.Content) },
+		Created:  &inst.result.Tasks,
+	}
+	if err := tree.Create(filled.Tasks, "", ""); err != nil {
+		return inst.result, err
+	}
+	for _, s := range filled.Sections {
+		section, err := client.CreateSection(todoist.SectionParams{ProjectID: inst.result.ProjectID, Name: s.Name})
+		if err != nil {
+			return inst.result, fmt.Errorf("blueprint: create section %q: %w", s.Name, err)
+		}
+		inst.result.Sections = append(inst.result.Sections, *section)
+		if err := tree.Create(s.Tasks, section.ID, ""); err != nil {
+			return inst.result, err
+		}
+	}
+	return inst.result, nil
+}
+
+// fill returns a copy of a template with its variables replaced.
+func fill(t *Template, vars map[string]string) (*Template, error) {
+	copied := *t
+	copied.Tasks = copyTasks(t.Tasks)
+	copied.Sections = make([]*Section, len(t.Sections))
+	for i, s := range t.Sections {
+		copied.Sections[i] = &Section{Name: s.Name, Tasks: copyTasks(s.Tasks)}
+	}
+
+	missing := make(map[string]bool)
+	copied.eachText(func(s *string) { *s = substitute(*s, vars, missing) })
+	if len(missing) > 0 {
+		var errs todoist.ValidationErrors
+		for _, name := range t.Variables() {
+			if missing[name] {
+				errs = append(errs, todoist.ValidationError{Field: "{{" + name + "}}", Message: "no value given"})
+			}
+		}
+		return nil, errs
+	}
+	return &copied, nil
+}
+
+func copyTasks(tasks []*Task) []*Task {
+	if tasks == nil {
+		return nil
+	}
+	copied := make([]*Task, len(tasks))
+	for i, t := range tasks {
+		c := *t
+		c.Labels = append([]string(nil), t.Labels...)
+		c.Subtasks = copyTasks(t.Subtasks)
+		copied[i] = &c
+	}
+	return copied
+}
+
+type instance struct {
+	base   time.Time
+	loc    *time.Location
+	result *Result
+}
+
+// validate checks the parameters of every task of a filled template.
+func (inst *instance) validate(t *Template) error {
+	var errs todoist.ValidationErrors
+	var walk func(path string, tasks []*Task)
+	walk = func(path string, tasks []*Task) {
+		for i, task := range tasks {
+			field := fmt.Sprintf("%s[%d]", path, i)
+			if _, err := inst.taskParams(task); err != nil {
+				errs = append(errs, todoist.ValidationError{Field: field, Message: err.Error()})
+			}
+			walk(field+".subtasks", task.Subtasks)
+		}
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		errs = append(errs, todoist.ValidationError{Field: "name", Message: "is required"})
+	}
+	walk("tasks", t.Tasks)
+	for i, s := range t.Sections {
+		if strings.TrimSpace(s.Name) == "" {
+			errs = append(errs, todoist.ValidationError{Field: fmt.Sprintf("sections[%d]", i), Message: "name is required"})
+		}
+		walk(fmt.Sprintf("sections[%d].tasks", i), s.Tasks)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// taskParams returns the parameters creating a task of a filled template, without its location.
+func (inst *instance) taskParams(t *Task) (todoist.TaskParams, error) {
+	params := todoist.TaskParams{
+		Content:     t.Content,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Labels:      t.Labels,
+		AssigneeID:  t.Assignee,
+		DueString:   t.DueString,
+	}
+	if d := t.Duration; d != nil && d.Amount > 0 {
+		params.Duration, params.DurationUnit = d.Amount, d.Unit
+	}
+
+	switch {
+	case t.DueDays != nil && t.DueString != "":
+		return params, fmt.Errorf("due_days and due_string are exclusive")
+	case t.DueDays == nil && t.DueTime != "":
+		return params, fmt.Errorf("due_time requires due_days")
+	case t.DueDays != nil:
+		day := inst.base.AddDate(0, 0, *t.DueDays)
+		if t.DueTime == "" {
+			params.DueDate = day.Format(time.DateOnly)
+			break
+		}
+		clock, err := time.Parse("15:04", t.DueTime)
+		if err != nil {
+			return params, fmt.Errorf("invalid due_time %q, want HH:MM", t.DueTime)
+		}
+		at := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, inst.loc)
+		params.DueDatetime = at.Format(time.RFC3339)
+	}
+	return params, params.Validate()
+}
@@ -0,0 +1,284 @@
+// Package cache keeps a local on-disk copy of a Todoist account that is kept up to date through incremental syncs.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Resource identifies a kind of data held in the cache.
+type Resource string
+
+const (
+	Projects Resource = "projects"
+	Sections Resource = "sections"
+	Tasks    Resource = "tasks"
+	Labels   Resource = "labels"
+	Comments Resource = "comments"
+)
+
+// AllResources lists every resource type held in the cache.
+var AllResources = []Resource{Projects, Sections, Tasks, Labels, Comments}
+
+// syncTypes maps each cached resource to the Sync API resource types it is built from.
+var syncTypes = map[Resource][]todoist.ResourceType{
+	Projects: {todoist.ResourceProjects},
+	Sections: {todoist.ResourceSections},
+	Tasks:    {todoist.ResourceItems},
+	Labels:   {todoist.ResourceLabels},
+	Comments: {todoist.ResourceNotes, todoist.ResourceProjectNotes},
+}
+
+// Policy defines how long each resource is served from disk before a read triggers a refresh.
+// Resources missing from the policy are only refreshed by explicit calls to Refresh.
+type Policy map[Resource]time.Duration
+
+// DefaultPolicy is used when no policy is given to Open.
+var DefaultPolicy = Policy{
+	Projects: 10 * time.Minute,
+	Sections: 10 * time.Minute,
+	Tasks:    time.Minute,
+	Labels:   time.Hour,
+	Comments: 5 * time.Minute,
+}
+
+// ErrNotFound is returned when a requested record is not in the cache.
+var ErrNotFound = errors.New("not found in cache")
+
+// Options configures a Store.
+type Options struct {
+	Policy     Policy
+	ServeStale bool // Serve cached data instead of failing when a refresh fails, e.g. while offline
+}
+
+// Store is a local copy of a Todoist account, persisted as JSON files in a directory.
+type Store struct {
+	client *todoist.TodoistClient
+	dir    string
+	opts   Options
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    state
+	projects map[string]todoist.SyncProject
+	sections map[string]todoist.SyncSection
+	items    map[string]todoist.SyncItem
+	labels   map[string]todoist.SyncLabel
+	notes    map[string]todoist.SyncNote
+}
+
+// state holds the sync tokens and refresh times of all resources.
+type state struct {
+	Tokens    map[Resource]string    `json:"tokens"`
+	Refreshed map[Resource]time.Time `json:"refreshed"`
+}
+
+// Open loads the cache stored in dir, creating the directory if needed.
+func Open(dir string, client *todoist.TodoistClient, opts Options) (*Store, error) {
+	if opts.Policy == nil {
+		opts.Policy = DefaultPolicy
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		client: client,
+		dir:    dir,
+		opts:   opts,
+		now:    time.Now,
+		state: state{
+			Tokens:    make(map[Resource]string),
+			Refreshed: make(map[Resource]time.Time),
+		},
+		projects: make(map[string]todoist.SyncProject),
+		sections: make(map[string]todoist.SyncSection),
+		items:    make(map[string]todoist.SyncItem),
+		labels:   make(map[string]todoist.SyncLabel),
+		notes:    make(map[string]todoist.SyncNote),
+	}
+
+	files := map[string]interface{}{
+		"state.json":               &s.state,
+		string(Projects) + ".json": &s.projects,
+		string(Sections) + ".json": &s.sections,
+		string(Tasks) + ".json":    &s.items,
+		string(Labels) + ".json":   &s.labels,
+		string(Comments) + ".json": &s.notes,
+	}
+	for name, target := range files {
+		if err := s.load(name, target); err != nil {
+			return nil, err
+		}
+	}
+	if s.state.Tokens == nil {
+		s.state.Tokens = make(map[Resource]string)
+	}
+	if s.state.Refreshed == nil {
+		s.state.Refreshed = make(map[Resource]time.Time)
+	}
+
+	return s, nil
+}
+
+// Refresh brings the given resources up to date, or all resources if none are given.
+func (s *Store) Refresh(resources ...Resource) error {
+	if len(resources) == 0 {
+		resources = AllResources
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range resources {
+		if err := s.refreshLocked(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Invalidate marks resources as stale so the next read refreshes them, or all resources if none are given.
+func (s *Store) Invalidate(resources ...Resource) {
+	if len(resources) == 0 {
+		resources = AllResources
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range resources {
+		delete(s.state.Refreshed, r)
+	}
+}
+
+// LastRefreshed returns when a resource was last refreshed, or the zero time if it never was.
+func (s *Store) LastRefreshed(r Resource) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.Refreshed[r]
+}
+
+// ensureFresh refreshes a resource if it is older than the policy allows. The caller must hold s.mu.
+func (s *Store) ensureFresh(r Resource) error {
+	refreshed, ok := s.state.Refreshed[r]
+	if ok {
+		maxAge, limited := s.opts.Policy[r]
+		if !limited || s.now().Sub(refreshed) < maxAge {
+			return nil
+		}
+	}
+
+	err := s.refreshLocked(r)
+	if err != nil && ok && s.opts.ServeStale {
+		return nil
+	}
+	return err
+}
+
+// refreshLocked applies an incremental sync of a resource and persists the result. The caller must hold s.mu.
+func (s *Store) refreshLocked(r Resource) error {
+	types, ok := syncTypes[r]
+	if !ok {
+		return fmt.Errorf("unknown cache resource %q", r)
+	}
+
+	resp, err := s.client.Sync(s.state.Tokens[r], types...)
+	if err != nil {
+		return fmt.Errorf("failed to refresh %s: %w", r, err)
+	}
+
+	var target interface{}
+	switch r {
+	case Projects:
+		apply(s.projects, resp.FullSync, resp.Projects, func(p todoist.SyncProject) (string, bool) { return p.ID, p.IsDeleted })
+		target = s.projects
+	case Sections:
+		apply(s.sections, resp.FullSync, resp.Sections, func(sec todoist.SyncSection) (string, bool) { return sec.ID, sec.IsDeleted })
+		target = s.sections
+	case Tasks:
+		apply(s.items, resp.FullSync, resp.Items, func(i todoist.SyncItem) (string, bool) { return i.ID, i.IsDeleted })
+		target = s.items
+	case Labels:
+		apply(s.labels, resp.FullSync, resp.Labels, func(l todoist.SyncLabel) (string, bool) { return l.ID, l.IsDeleted })
+		target = s.labels
+	case Comments:
+		notes := append(resp.Notes, resp.ProjectNotes...)
+		apply(s.notes, resp.FullSync, notes, func(n todoist.SyncNote) (string, bool) { return n.ID, n.IsDeleted })
+		target = s.notes
+	}
+
+	if err := s.save(string(r)+".json", target); err != nil {
+		return err
+	}
+
+	s.state.Tokens[r] = resp.SyncToken
+	s.state.Refreshed[r] = s.now()
+	return s.save("state.json", s.state)
+}
+
+// apply merges synced records into a resource map, replacing it entirely on a full sync.
+func apply[T any](records map[string]T, fullSync bool, changes []T, key func(T) (string, bool)) {
+	if fullSync {
+		for id := range records {
+			delete(records, id)
+		}
+	}
+
+	for _, record := range changes {
+		id, deleted := key(record)
+		if deleted {
+			delete(records, id)
+			continue
+		}
+		records[id] = record
+	}
+}
+
+// load decodes a JSON file of the cache directory into target, ignoring missing files.
+func (s *Store) load(name string, target interface{}) error {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("corrupt cache file %s: %w", name, err)
+	}
+	return nil
+}
+
+// save atomically writes value as a JSON file into the cache directory.
+func (s *Store) save(name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
@@ -0,0 +1,259 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/filter"
+)
+
+// GetProjects returns all active projects, like TodoistClient.GetProjects.
+func (s *Store) GetProjects() ([]todoist.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Projects); err != nil {
+		return nil, err
+	}
+
+	projects := make([]todoist.Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		if !p.IsArchived {
+			projects = append(projects, p.ToProject())
+		}
+	}
+	sort.SliceStable(projects, func(i, j int) bool { return projects[i].Order < projects[j].Order })
+
+	return projects, nil
+}
+
+// GetProject returns a specific project by its ID.
+func (s *Store) GetProject(id string) (*todoist.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Projects); err != nil {
+		return nil, err
+	}
+
+	p, ok := s.projects[id]
+	if !ok {
+		return nil, fmt.Errorf("project %s: %w", id, ErrNotFound)
+	}
+
+	project := p.ToProject()
+	return &project, nil
+}
+
+// GetSections returns all sections, or only those of a project if projectID is not empty.
+func (s *Store) GetSections(projectID string) ([]todoist.Section, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Sections); err != nil {
+		return nil, err
+	}
+
+	sections := make([]todoist.Section, 0)
+	for _, sec := range s.sections {
+		if sec.IsArchived || (projectID != "" && sec.ProjectID != projectID) {
+			continue
+		}
+		sections = append(sections, sec.ToSection())
+	}
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Order < sections[j].Order })
+
+	return sections, nil
+}
+
+// GetSection returns a specific section by its ID.
+func (s *Store) GetSection(id string) (*todoist.Section, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Sections); err != nil {
+		return nil, err
+	}
+
+	sec, ok := s.sections[id]
+	if !ok {
+		return nil, fmt.Errorf("section %s: %w", id, ErrNotFound)
+	}
+
+	section := sec.ToSection()
+	return &section, nil
+}
+
+// GetTasks returns all active tasks, optionally filtered by project, section, or label like TodoistClient.GetTasks.
+func (s *Store) GetTasks(projectID, sectionID, label string) ([]todoist.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Tasks); err != nil {
+		return nil, err
+	}
+
+	commentCounts := s.taskCommentCounts()
+	tasks := make([]todoist.Task, 0)
+	for _, item := range s.items {
+		if item.Checked || !matchesTaskFilter(item, projectID, sectionID, label) {
+			continue
+		}
+		task := item.ToTask()
+		task.CommentCount = commentCounts[item.ID]
+		tasks = append(tasks, task)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Order < tasks[j].Order })
+
+	return tasks, nil
+}
+
+// GetTask returns a specific task by its ID, including completed tasks still known to the cache.
+func (s *Store) GetTask(id string) (*todoist.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Tasks); err != nil {
+		return nil, err
+	}
+
+	item, ok := s.items[id]
+	if !ok {
+		return nil, fmt.Errorf("task %s: %w", id, ErrNotFound)
+	}
+
+	task := item.ToTask()
+	task.CommentCount = s.taskCommentCounts()[id]
+	return &task, nil
+}
+
+// GetLabels returns all personal labels.
+func (s *Store) GetLabels() ([]todoist.Label, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Labels); err != nil {
+		return nil, err
+	}
+
+	labels := make([]todoist.Label, 0, len(s.labels))
+	for _, l := range s.labels {
+		labels = append(labels, l.ToLabel())
+	}
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].Order < labels[j].Order })
+
+	return labels, nil
+}
+
+// GetLabel returns a specific personal label by its ID.
+func (s *Store) GetLabel(id string) (*todoist.Label, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Labels); err != nil {
+		return nil, err
+	}
+
+	l, ok := s.labels[id]
+	if !ok {
+		return nil, fmt.Errorf("label %s: %w", id, ErrNotFound)
+	}
+
+	label := l.ToLabel()
+	return &label, nil
+}
+
+// GetComments returns the comments of a task or project, like TodoistClient.GetComments.
+func (s *Store) GetComments(taskID, projectID string) ([]todoist.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Comments); err != nil {
+		return nil, err
+	}
+
+	comments := make([]todoist.Comment, 0)
+	for _, note := range s.notes {
+		comment := note.ToComment()
+		if taskID != "" && comment.TaskID != taskID {
+			continue
+		}
+		if taskID == "" && projectID != "" && comment.ProjectID != projectID {
+			continue
+		}
+		comments = append(comments, comment)
+	}
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].PostedAt < comments[j].PostedAt })
+
+	return comments, nil
+}
+
+// GetComment returns a specific comment by its ID.
+func (s *Store) GetComment(id string) (*todoist.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFresh(Comments); err != nil {
+		return nil, err
+	}
+
+	note, ok := s.notes[id]
+	if !ok {
+		return nil, fmt.Errorf("comment %s: %w", id, ErrNotFound)
+	}
+
+	comment := note.ToComment()
+	return &comment, nil
+}
+
+// taskCommentCounts counts the cached comments per task ID. The caller must hold s.mu.
+func (s *Store) taskCommentCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, note := range s.notes {
+		if note.ItemID != "" {
+			counts[note.ItemID]++
+		}
+	}
+	return counts
+}
+
+// matchesTaskFilter applies the filters of GetTasks, where the project takes precedence over section and label.
+func matchesTaskFilter(item todoist.SyncItem, projectID, sectionID, label string) bool {
+	switch {
+	case projectID != "":
+		return item.ProjectID == projectID
+	case sectionID != "":
+		return item.SectionID == sectionID
+	case label != "":
+		for _, l := range item.Labels {
+			if l == label {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FilterTasks returns the active tasks matching a query of Todoist's filter language, such as
+// "(today | overdue) & #Work", evaluated on the cached data. See the filter package for the syntax.
+func (s *Store) FilterTasks(query string) ([]todoist.Task, error) {
+	f, err := filter.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.GetSections("")
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.GetTasks("", "", "")
+	if err != nil {
+		return nil, err
+	}
+	return f.Apply(tasks, &filter.Env{Projects: projects, Sections: sections}), nil
+}
@@ -1,32 +1,61 @@
 package todoist
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 // TodoistClient represents the Todoist API client.
 type TodoistClient struct {
-	BaseURL    string
-	Token      string
-	HTTPClient *http.Client
+	BaseURL     string
+	SyncBaseURL string
+	Token       string
+	TokenSource TokenSource // Takes precedence over Token when set
+	HTTPClient  *http.Client
 }
 
 // NewClient initializes a new Todoist API client.
 func NewTodoistClient(token string) *TodoistClient {
 	return &TodoistClient{
-		BaseURL:    "https://api.todoist.com/rest/v2",
-		Token:      token,
-		HTTPClient: &http.Client{},
+		BaseURL:     "https://api.todoist.com/rest/v2",
+		SyncBaseURL: "https://api.todoist.com/sync/v9",
+		Token:       token,
+		HTTPClient:  &http.Client{},
 	}
 }
 
+// NewTodoistClientWithTokenSource initializes a new Todoist API client that gets its tokens from ts.
+func NewTodoistClientWithTokenSource(ts TokenSource) *TodoistClient {
+	client := NewTodoistClient("")
+	client.TokenSource = ts
+	return client
+}
+
+// tokenSource returns the token source used for requests.
+func (c *TodoistClient) tokenSource() TokenSource {
+	if c.TokenSource != nil {
+		return c.TokenSource
+	}
+	return StaticTokenSource(c.Token)
+}
+
 // CreateProject creates a new project on Todoist.
 func (c *TodoistClient) CreateProject(params ProjectParams) (*Project, error) {
+	return c.CreateProjectContext(context.Background(), params)
+}
+
+// CreateProjectContext is like CreateProject but uses ctx for the request.
+func (c *TodoistClient) CreateProjectContext(ctx context.Context, params ProjectParams) (*Project, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/projects", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +70,18 @@ func (c *TodoistClient) CreateProject(params ProjectParams) (*Project, error) {
 
 // UpdateProject updates an existing project on Todoist.
 func (c *TodoistClient) UpdateProject(id string, params ProjectParams) (*Project, error) {
+	return c.UpdateProjectContext(context.Background(), id, params)
+}
+
+// UpdateProjectContext is like UpdateProject but uses ctx for the request.
+func (c *TodoistClient) UpdateProjectContext(ctx context.Context, id string, params ProjectParams) (*Project, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/projects/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -58,9 +96,14 @@ func (c *TodoistClient) UpdateProject(id string, params ProjectParams) (*Project
 
 // GetProject fetches a specific project by its ID from Todoist.
 func (c *TodoistClient) GetProject(id string) (*Project, error) {
+	return c.GetProjectContext(context.Background(), id)
+}
+
+// GetProjectContext is like GetProject but uses ctx for the request.
+func (c *TodoistClient) GetProjectContext(ctx context.Context, id string) (*Project, error) {
 	url := fmt.Sprintf("%s/projects/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -75,11 +118,16 @@ func (c *TodoistClient) GetProject(id string) (*Project, error) {
 
 // GetProjects fetches all projects from the Todoist API.
 func (c *TodoistClient) GetProjects() ([]Project, error) {
+	return c.GetProjectsContext(context.Background())
+}
+
+// GetProjectsContext is like GetProjects but uses ctx for the request.
+func (c *TodoistClient) GetProjectsContext(ctx context.Context) ([]Project, error) {
 	// Build the request URL
 	url := fmt.Sprintf("%s/projects", c.BaseURL)
 
 	// Use the sendRequest utility function to perform the GET request
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -100,15 +148,20 @@ func (c *TodoistClient) GetProjects() ([]Project, error) {
 
 // DeleteProject deletes a specific project by its ID from Todoist.
 func (c *TodoistClient) DeleteProject(id string) (bool, error) {
+	return c.DeleteProjectContext(context.Background(), id)
+}
+
+// DeleteProjectContext is like DeleteProject but uses ctx for the request.
+func (c *TodoistClient) DeleteProjectContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/projects/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "DELETE", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "DELETE", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to delete project, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "delete project"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -116,12 +169,17 @@ func (c *TodoistClient) DeleteProject(id string) (bool, error) {
 
 // GetSections fetches all sections for a given project from Todoist.
 func (c *TodoistClient) GetSections(projectID string) ([]Section, error) {
+	return c.GetSectionsContext(context.Background(), projectID)
+}
+
+// GetSectionsContext is like GetSections but uses ctx for the request.
+func (c *TodoistClient) GetSectionsContext(ctx context.Context, projectID string) ([]Section, error) {
 	url := fmt.Sprintf("%s/sections", c.BaseURL)
 	if projectID != "" {
 		url = fmt.Sprintf("%s?project_id=%s", url, projectID)
 	}
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -136,9 +194,18 @@ func (c *TodoistClient) GetSections(projectID string) ([]Section, error) {
 
 // CreateSection creates a new section on Todoist.
 func (c *TodoistClient) CreateSection(params SectionParams) (*Section, error) {
+	return c.CreateSectionContext(context.Background(), params)
+}
+
+// CreateSectionContext is like CreateSection but uses ctx for the request.
+func (c *TodoistClient) CreateSectionContext(ctx context.Context, params SectionParams) (*Section, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/sections", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -153,9 +220,14 @@ func (c *TodoistClient) CreateSection(params SectionParams) (*Section, error) {
 
 // GetSection fetches a specific section by its ID from Todoist.
 func (c *TodoistClient) GetSection(id string) (*Section, error) {
+	return c.GetSectionContext(context.Background(), id)
+}
+
+// GetSectionContext is like GetSection but uses ctx for the request.
+func (c *TodoistClient) GetSectionContext(ctx context.Context, id string) (*Section, error) {
 	url := fmt.Sprintf("%s/sections/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -170,9 +242,18 @@ func (c *TodoistClient) GetSection(id string) (*Section, error) {
 
 // UpdateSection updates a specific section by its ID on Todoist.
 func (c *TodoistClient) UpdateSection(id string, params SectionParams) (*Section, error) {
+	return c.UpdateSectionContext(context.Background(), id, params)
+}
+
+// UpdateSectionContext is like UpdateSection but uses ctx for the request.
+func (c *TodoistClient) UpdateSectionContext(ctx context.Context, id string, params SectionParams) (*Section, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/sections/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -187,15 +268,20 @@ func (c *TodoistClient) UpdateSection(id string, params SectionParams) (*Section
 
 // DeleteSection deletes a specific section by its ID from Todoist.
 func (c *TodoistClient) DeleteSection(id string) (bool, error) {
+	return c.DeleteSectionContext(context.Background(), id)
+}
+
+// DeleteSectionContext is like DeleteSection but uses ctx for the request.
+func (c *TodoistClient) DeleteSectionContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/sections/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "DELETE", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "DELETE", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to delete section, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "delete section"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -203,6 +289,11 @@ func (c *TodoistClient) DeleteSection(id string) (bool, error) {
 
 // GetTasks fetches all active tasks from Todoist, optionally filtered by project, section, or label.
 func (c *TodoistClient) GetTasks(projectID, sectionID, label string) ([]Task, error) {
+	return c.GetTasksContext(context.Background(), projectID, sectionID, label)
+}
+
+// GetTasksContext is like GetTasks but uses ctx for the request.
+func (c *TodoistClient) GetTasksContext(ctx context.Context, projectID, sectionID, label string) ([]Task, error) {
 	url := fmt.Sprintf("%s/tasks", c.BaseURL)
 
 	// Apply filters
@@ -214,7 +305,30 @@ func (c *TodoistClient) GetTasks(projectID, sectionID, label string) ([]Task, er
 		url = fmt.Sprintf("%s?label=%s", url, label)
 	}
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	if err := parseResponse(resp, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// GetTasksByFilter fetches the active tasks matching a filter query, such as "today & #Work".
+// The query is evaluated by Todoist; see the filter package to evaluate queries locally.
+func (c *TodoistClient) GetTasksByFilter(query string) ([]Task, error) {
+	return c.GetTasksByFilterContext(context.Background(), query)
+}
+
+// GetTasksByFilterContext is like GetTasksByFilter but uses ctx for the request.
+func (c *TodoistClient) GetTasksByFilterContext(ctx context.Context, query string) ([]Task, error) {
+	url := fmt.Sprintf("%s/tasks?filter=%s", c.BaseURL, url.QueryEscape(query))
+
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -229,9 +343,18 @@ func (c *TodoistClient) GetTasks(projectID, sectionID, label string) ([]Task, er
 
 // CreateTask creates a new task on Todoist.
 func (c *TodoistClient) CreateTask(params TaskParams) (*Task, error) {
+	return c.CreateTaskContext(context.Background(), params)
+}
+
+// CreateTaskContext is like CreateTask but uses ctx for the request.
+func (c *TodoistClient) CreateTaskContext(ctx context.Context, params TaskParams) (*Task, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/tasks", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -246,9 +369,14 @@ func (c *TodoistClient) CreateTask(params TaskParams) (*Task, error) {
 
 // GetTask fetches a specific task by its ID from Todoist.
 func (c *TodoistClient) GetTask(id string) (*Task, error) {
+	return c.GetTaskContext(context.Background(), id)
+}
+
+// GetTaskContext is like GetTask but uses ctx for the request.
+func (c *TodoistClient) GetTaskContext(ctx context.Context, id string) (*Task, error) {
 	url := fmt.Sprintf("%s/tasks/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -263,9 +391,42 @@ func (c *TodoistClient) GetTask(id string) (*Task, error) {
 
 // UpdateTask updates a specific task by its ID on Todoist.
 func (c *TodoistClient) UpdateTask(id string, params TaskParams) (*Task, error) {
+	return c.UpdateTaskContext(context.Background(), id, params)
+}
+
+// UpdateTaskContext is like UpdateTask but uses ctx for the request.
+func (c *TodoistClient) UpdateTaskContext(ctx context.Context, id string, params TaskParams) (*Task, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/tasks/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := parseResponse(resp, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// ClearTaskLabels removes all labels from a task, which UpdateTask cannot do as it leaves
+// empty fields out.
+func (c *TodoistClient) ClearTaskLabels(id string) (*Task, error) {
+	return c.ClearTaskLabelsContext(context.Background(), id)
+}
+
+// ClearTaskLabelsContext is like ClearTaskLabels but uses ctx for the request.
+func (c *TodoistClient) ClearTaskLabelsContext(ctx context.Context, id string) (*Task, error) {
+	url := fmt.Sprintf("%s/tasks/%s", c.BaseURL, id)
+
+	body := map[string][]string{"labels": {}}
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -280,15 +441,20 @@ func (c *TodoistClient) UpdateTask(id string, params TaskParams) (*Task, error)
 
 // CloseTask closes a specific task by its ID on Todoist.
 func (c *TodoistClient) CloseTask(id string) (bool, error) {
+	return c.CloseTaskContext(context.Background(), id)
+}
+
+// CloseTaskContext is like CloseTask but uses ctx for the request.
+func (c *TodoistClient) CloseTaskContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/tasks/%s/close", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to close task, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "close task"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -296,15 +462,20 @@ func (c *TodoistClient) CloseTask(id string) (bool, error) {
 
 // ReopenTask reopens a specific task by its ID on Todoist.
 func (c *TodoistClient) ReopenTask(id string) (bool, error) {
+	return c.ReopenTaskContext(context.Background(), id)
+}
+
+// ReopenTaskContext is like ReopenTask but uses ctx for the request.
+func (c *TodoistClient) ReopenTaskContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/tasks/%s/reopen", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to reopen task, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "reopen task"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -312,15 +483,20 @@ func (c *TodoistClient) ReopenTask(id string) (bool, error) {
 
 // DeleteTask deletes a specific task by its ID from Todoist.
 func (c *TodoistClient) DeleteTask(id string) (bool, error) {
+	return c.DeleteTaskContext(context.Background(), id)
+}
+
+// DeleteTaskContext is like DeleteTask but uses ctx for the request.
+func (c *TodoistClient) DeleteTaskContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/tasks/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "DELETE", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "DELETE", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to delete task, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "delete task"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -328,6 +504,11 @@ func (c *TodoistClient) DeleteTask(id string) (bool, error) {
 
 // GetComments fetches all comments for a given task or project from Todoist.
 func (c *TodoistClient) GetComments(taskID, projectID string) ([]Comment, error) {
+	return c.GetCommentsContext(context.Background(), taskID, projectID)
+}
+
+// GetCommentsContext is like GetComments but uses ctx for the request.
+func (c *TodoistClient) GetCommentsContext(ctx context.Context, taskID, projectID string) ([]Comment, error) {
 	url := fmt.Sprintf("%s/comments", c.BaseURL)
 
 	// Apply filters
@@ -337,7 +518,7 @@ func (c *TodoistClient) GetComments(taskID, projectID string) ([]Comment, error)
 		url = fmt.Sprintf("%s?project_id=%s", url, projectID)
 	}
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -352,9 +533,18 @@ func (c *TodoistClient) GetComments(taskID, projectID string) ([]Comment, error)
 
 // CreateComment creates a new comment on a task or project.
 func (c *TodoistClient) CreateComment(params CommentParams) (*Comment, error) {
+	return c.CreateCommentContext(context.Background(), params)
+}
+
+// CreateCommentContext is like CreateComment but uses ctx for the request.
+func (c *TodoistClient) CreateCommentContext(ctx context.Context, params CommentParams) (*Comment, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/comments", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -369,9 +559,14 @@ func (c *TodoistClient) CreateComment(params CommentParams) (*Comment, error) {
 
 // GetComment fetches a specific comment by its ID from Todoist.
 func (c *TodoistClient) GetComment(id string) (*Comment, error) {
+	return c.GetCommentContext(context.Background(), id)
+}
+
+// GetCommentContext is like GetComment but uses ctx for the request.
+func (c *TodoistClient) GetCommentContext(ctx context.Context, id string) (*Comment, error) {
 	url := fmt.Sprintf("%s/comments/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -386,9 +581,18 @@ func (c *TodoistClient) GetComment(id string) (*Comment, error) {
 
 // UpdateComment updates a specific comment by its ID on Todoist.
 func (c *TodoistClient) UpdateComment(id string, params CommentParams) (*Comment, error) {
+	return c.UpdateCommentContext(context.Background(), id, params)
+}
+
+// UpdateCommentContext is like UpdateComment but uses ctx for the request.
+func (c *TodoistClient) UpdateCommentContext(ctx context.Context, id string, params CommentParams) (*Comment, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/comments/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -403,15 +607,20 @@ func (c *TodoistClient) UpdateComment(id string, params CommentParams) (*Comment
 
 // DeleteComment deletes a specific comment by its ID from Todoist.
 func (c *TodoistClient) DeleteComment(id string) (bool, error) {
+	return c.DeleteCommentContext(context.Background(), id)
+}
+
+// DeleteCommentContext is like DeleteComment but uses ctx for the request.
+func (c *TodoistClient) DeleteCommentContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/comments/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "DELETE", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "DELETE", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to delete comment, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "delete comment"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -419,9 +628,14 @@ func (c *TodoistClient) DeleteComment(id string) (bool, error) {
 
 // GetLabels fetches all personal labels from Todoist.
 func (c *TodoistClient) GetLabels() ([]Label, error) {
+	return c.GetLabelsContext(context.Background())
+}
+
+// GetLabelsContext is like GetLabels but uses ctx for the request.
+func (c *TodoistClient) GetLabelsContext(ctx context.Context) ([]Label, error) {
 	url := fmt.Sprintf("%s/labels", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -436,9 +650,18 @@ func (c *TodoistClient) GetLabels() ([]Label, error) {
 
 // CreateLabel creates a new personal label on Todoist.
 func (c *TodoistClient) CreateLabel(params LabelParams) (*Label, error) {
+	return c.CreateLabelContext(context.Background(), params)
+}
+
+// CreateLabelContext is like CreateLabel but uses ctx for the request.
+func (c *TodoistClient) CreateLabelContext(ctx context.Context, params LabelParams) (*Label, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/labels", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -453,9 +676,14 @@ func (c *TodoistClient) CreateLabel(params LabelParams) (*Label, error) {
 
 // GetLabel fetches a specific personal label by its ID from Todoist.
 func (c *TodoistClient) GetLabel(id string) (*Label, error) {
+	return c.GetLabelContext(context.Background(), id)
+}
+
+// GetLabelContext is like GetLabel but uses ctx for the request.
+func (c *TodoistClient) GetLabelContext(ctx context.Context, id string) (*Label, error) {
 	url := fmt.Sprintf("%s/labels/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -470,9 +698,18 @@ func (c *TodoistClient) GetLabel(id string) (*Label, error) {
 
 // UpdateLabel updates a specific personal label by its ID on Todoist.
 func (c *TodoistClient) UpdateLabel(id string, params LabelParams) (*Label, error) {
+	return c.UpdateLabelContext(context.Background(), id, params)
+}
+
+// UpdateLabelContext is like UpdateLabel but uses ctx for the request.
+func (c *TodoistClient) UpdateLabelContext(ctx context.Context, id string, params LabelParams) (*Label, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/labels/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return nil, err
 	}
@@ -487,15 +724,20 @@ func (c *TodoistClient) UpdateLabel(id string, params LabelParams) (*Label, erro
 
 // DeleteLabel deletes a specific personal label by its ID from Todoist.
 func (c *TodoistClient) DeleteLabel(id string) (bool, error) {
+	return c.DeleteLabelContext(context.Background(), id)
+}
+
+// DeleteLabelContext is like DeleteLabel but uses ctx for the request.
+func (c *TodoistClient) DeleteLabelContext(ctx context.Context, id string) (bool, error) {
 	url := fmt.Sprintf("%s/labels/%s", c.BaseURL, id)
 
-	resp, err := sendRequest(c.HTTPClient, "DELETE", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "DELETE", url, c.tokenSource(), nil)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to delete label, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "delete label"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -503,13 +745,18 @@ func (c *TodoistClient) DeleteLabel(id string) (bool, error) {
 
 // GetSharedLabels fetches all shared labels from Todoist.
 func (c *TodoistClient) GetSharedLabels(omitPersonal bool) ([]string, error) {
+	return c.GetSharedLabelsContext(context.Background(), omitPersonal)
+}
+
+// GetSharedLabelsContext is like GetSharedLabels but uses ctx for the request.
+func (c *TodoistClient) GetSharedLabelsContext(ctx context.Context, omitPersonal bool) ([]string, error) {
 	url := fmt.Sprintf("%s/labels/shared", c.BaseURL)
 
 	if omitPersonal {
 		url = fmt.Sprintf("%s?omit_personal=true", url)
 	}
 
-	resp, err := sendRequest(c.HTTPClient, "GET", url, c.Token, nil)
+	resp, err := sendRequest(ctx, c.HTTPClient, "GET", url, c.tokenSource(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -524,15 +771,24 @@ func (c *TodoistClient) GetSharedLabels(omitPersonal bool) ([]string, error) {
 
 // RenameSharedLabel renames a shared label on Todoist.
 func (c *TodoistClient) RenameSharedLabel(params SharedLabelParams) (bool, error) {
+	return c.RenameSharedLabelContext(context.Background(), params)
+}
+
+// RenameSharedLabelContext is like RenameSharedLabel but uses ctx for the request.
+func (c *TodoistClient) RenameSharedLabelContext(ctx context.Context, params SharedLabelParams) (bool, error) {
+	if err := params.validateRename(); err != nil {
+		return false, err
+	}
+
 	url := fmt.Sprintf("%s/labels/shared/rename", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to rename shared label, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "rename shared label"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -540,15 +796,24 @@ func (c *TodoistClient) RenameSharedLabel(params SharedLabelParams) (bool, error
 
 // RemoveSharedLabel removes a shared label from Todoist.
 func (c *TodoistClient) RemoveSharedLabel(params SharedLabelParams) (bool, error) {
+	return c.RemoveSharedLabelContext(context.Background(), params)
+}
+
+// RemoveSharedLabelContext is like RemoveSharedLabel but uses ctx for the request.
+func (c *TodoistClient) RemoveSharedLabelContext(ctx context.Context, params SharedLabelParams) (bool, error) {
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+
 	url := fmt.Sprintf("%s/labels/shared/remove", c.BaseURL)
 
-	resp, err := sendRequest(c.HTTPClient, "POST", url, c.Token, params)
+	resp, err := sendRequest(ctx, c.HTTPClient, "POST", url, c.tokenSource(), params)
 	if err != nil {
 		return false, err
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return false, fmt.Errorf("failed to remove shared label, status code: %d", resp.StatusCode)
+	if err := checkNoContent(resp, "remove shared label"); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/credentials"
+)
+
+// errNoToken is returned when no token is configured for an invocation.
+var errNoToken = errors.New("no API token, use -token, $TODOIST_TOKEN or \"todoist auth login\"")
+
+var authActions = map[string]action{
+	"login":   {"-profile NAME [-token TOKEN] [-default]", "store a token, read from stdin if not given", authLogin},
+	"list":    {"", "list stored profiles", authList},
+	"default": {"NAME", "make a profile the default", authDefault},
+	"remove":  {"NAME...", "delete stored profiles", authRemove},
+}
+
+// Client returns the client of the invocation. The token is taken from the -token flag,
+// then $TODOIST_TOKEN, then the selected or default profile of the credentials store.
+func (e *env) Client() (*todoist.TodoistClient, error) {
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	token := e.token
+	if token == "" && e.profile == "" {
+		token = os.Getenv("TODOIST_TOKEN")
+	}
+	if token != "" {
+		e.client = e.configure(todoist.NewTodoistClient(token))
+		return e.client, nil
+	}
+
+	path, err := e.credentialsFile()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, errNoToken
+	}
+
+	store, err := e.openStore()
+	if err != nil {
+		return nil, err
+	}
+	client, err := store.Client(e.profile)
+	if err != nil {
+		return nil, err
+	}
+	e.client = e.configure(client)
+	return e.client, nil
+}
+
+// configure points a client at the server given with -api-url, e.g. a local test server.
+func (e *env) configure(client *todoist.TodoistClient) *todoist.TodoistClient {
+	if e.apiURL != "" {
+		base := strings.TrimSuffix(e.apiURL, "/")
+		client.BaseURL = base + "/rest/v2"
+		client.SyncBaseURL = base + "/sync/v9"
+	}
+	return client
+}
+
+// credentialsFile returns the path of the credentials file, by default in the user's config directory.
+func (e *env) credentialsFile() (string, error) {
+	if e.credentialsPath != "" {
+		return e.credentialsPath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "todoist", "credentials.json"), nil
+}
+
+// openStore opens the credentials store with the key given by $TODOIST_KEY_FILE or $TODOIST_PASSPHRASE.
+func (e *env) openStore() (*credentials.Store, error) {
+	path, err := e.credentialsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var key credentials.Key
+	switch {
+	case os.Getenv("TODOIST_KEY_FILE") != "":
+		if key, err = credentials.KeyFile(os.Getenv("TODOIST_KEY_FILE")); err != nil {
+			return nil, err
+		}
+	case os.Getenv("TODOIST_PASSPHRASE") != "":
+		key = credentials.Passphrase(os.Getenv("TODOIST_PASSPHRASE"))
+	default:
+		return nil, fmt.Errorf("%w: set $TODOIST_PASSPHRASE or $TODOIST_KEY_FILE to unlock %s", errNoToken, path)
+	}
+
+	return credentials.Open(path, key)
+}
+
+func authLogin(e *env, args []string) error {
+	fs := e.newFlagSet("auth login")
+	profile := fs.String("profile", e.profile, "profile name")
+	token := fs.String("token", e.token, "API token")
+	makeDefault := fs.Bool("default", false, "make the profile the default")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *profile == "" {
+		return usagef("auth login: missing -profile")
+	}
+
+	if *token == "" {
+		fmt.Fprint(e.stderr, "API token: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+		*token = strings.TrimSpace(line)
+	}
+	if *token == "" {
+		return usagef("auth login: empty token")
+	}
+
+	store, err := e.openStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Set(*profile, credentials.Credential{Kind: credentials.KindPersonal, AccessToken: *token}); err != nil {
+		return err
+	}
+	if *makeDefault {
+		return store.SetDefault(*profile)
+	}
+	return nil
+}
+
+func authList(e *env, args []string) error {
+	fs := e.newFlagSet("auth list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	store, err := e.openStore()
+	if err != nil {
+		return err
+	}
+
+	type profileRow struct {
+		Name      string           `json:"name"`
+		Kind      credentials.Kind `json:"kind"`
+		Default   bool             `json:"default"`
+		Expired   bool             `json:"expired"`
+		UpdatedAt string           `json:"updated_at"`
+	}
+
+	var rows []profileRow
+	for _, name := range store.Profiles() {
+		cred, err := store.Get(name)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, profileRow{
+			Name:      name,
+			Kind:      cred.Kind,
+			Default:   name == store.Default(),
+			Expired:   cred.Expired(),
+			UpdatedAt: cred.UpdatedAt.Format("2006-01-02 15:04"),
+		})
+	}
+
+	return printList(e, rows, []column[profileRow]{
+		{"NAME", func(p profileRow) string { return p.Name }},
+		{"KIND", func(p profileRow) string { return string(p.Kind) }},
+		{"DEFAULT", func(p profileRow) string { return yesNo(p.Default) }},
+		{"EXPIRED", func(p profileRow) string { return yesNo(p.Expired) }},
+		{"UPDATED", func(p profileRow) string { return p.UpdatedAt }},
+	})
+}
+
+func authDefault(e *env, args []string) error {
+	fs := e.newFlagSet("auth default")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	names, err := requireArgs(fs, 1, "profile name")
+	if err != nil {
+		return err
+	}
+
+	store, err := e.openStore()
+	if err != nil {
+		return err
+	}
+	return store.SetDefault(names[0])
+}
+
+func authRemove(e *env, args []string) error {
+	fs := e.newFlagSet("auth remove")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	names, err := requireArgs(fs, 1, "profile name")
+	if err != nil {
+		return err
+	}
+
+	store, err := e.openStore()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := store.Delete(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/felixschmelzer/todoist-go/backup"
+)
+
+var backupActions = map[string]action{
+	"create":  {"[-o FILE]", "write a JSON archive of the whole account", backupCreate},
+	"restore": {"[-keep-assignees] FILE", "recreate an archive in the account, - reads stdin", backupRestore},
+}
+
+// idMapping is one restored record, listed by backup restore.
+type idMapping struct {
+	Type  string `json:"type"`
+	OldID string `json:"old_id"`
+	NewID string `json:"new_id"`
+}
+
+var idMappingColumns = []column[idMapping]{
+	{"TYPE", func(m idMapping) string { return m.Type }},
+	{"OLD ID", func(m idMapping) string { return m.OldID }},
+	{"NEW ID", func(m idMapping) string { return m.NewID }},
+}
+
+func backupCreate(e *env, args []string) error {
+	fs := e.newFlagSet("backup create")
+	output := fs.String("o", "", "write to `FILE` instead of stdout")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	archive, err := backup.Create(client, backup.Options{Progress: e.reportProgress})
+	if err != nil {
+		return err
+	}
+	return writeOutput(e, *output, func(w io.Writer) error {
+		return backup.Write(w, archive)
+	})
+}
+
+func backupRestore(e *env, args []string) error {
+	fs := e.newFlagSet("backup restore")
+	opts := backup.RestoreOptions{Progress: e.reportProgress}
+	fs.BoolVar(&opts.KeepAssignees, "keep-assignees", false, "assign tasks to the users of the archive")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	files, err := requireArgs(fs, 1, "file")
+	if err != nil {
+		return err
+	}
+
+	in, err := openInput(e, files[0])
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	archive, err := backup.Read(in)
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	result, err := backup.Restore(client, archive, opts)
+	if result != nil {
+		if printErr := printList(e, idMappings(result.IDs), idMappingColumns); err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
+
+// reportProgress writes a line to stderr when a stage of a backup or restore is done.
+func (e *env) reportProgress(p backup.Progress) {
+	if p.Done == p.Total {
+		fmt.Fprintf(e.stderr, "%s: %d\n", p.Stage, p.Total)
+	}
+}
+
+// idMappings lists the IDs of a restore by type and old ID.
+func idMappings(ids backup.IDMap) []idMapping {
+	var list []idMapping
+	for _, m := range []struct {
+		typ string
+		ids map[string]string
+	}{
+		{"label", ids.Labels},
+		{"project", ids.Projects},
+		{"section", ids.Sections},
+		{"task", ids.Tasks},
+		{"comment", ids.Comments},
+	} {
+		start := len(list)
+		for oldID, newID := range m.ids {
+			list = append(list, idMapping{Type: m.typ, OldID: oldID, NewID: newID})
+		}
+		part := list[start:]
+		sort.Slice(part, func(i, j int) bool { return part[i].OldID < part[j].OldID })
+	}
+	return list
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go/blueprint"
+)
+
+func projectCapture(e *env, args []string) error {
+	fs := e.newFlagSet("projects capture")
+	output := fs.String("o", "", "write to `FILE` instead of stdout")
+	base := fs.String("base", "", "count due offsets from `DATE` (YYYY-MM-DD), default the earliest due date")
+	vars := fs.String("vars", "", "comma separated `NAME=VALUE` pairs whose values become variables")
+	assignees := fs.Bool("assignees", false, "keep the users tasks are assigned to")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "project ID")
+	if err != nil {
+		return err
+	}
+	opts := blueprint.CaptureOptions{Assignees: *assignees}
+	if opts.BaseDate, err = parseBaseDate(fs.Name(), *base); err != nil {
+		return err
+	}
+	if opts.Variables, err = parseVars(fs.Name(), *vars); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	tmpl, err := blueprint.Capture(client, ids[0], opts)
+	if err != nil {
+		return err
+	}
+	return writeOutput(e, *output, func(w io.Writer) error {
+		return blueprint.Write(w, tmpl)
+	})
+}
+
+func projectInstantiate(e *env, args []string) error {
+	fs := e.newFlagSet("projects instantiate")
+	opts := blueprint.InstantiateOptions{}
+	fs.StringVar(&opts.ProjectID, "project", "", "create the tasks in the existing project `ID`")
+	fs.StringVar(&opts.Name, "name", "", "name of the new project, default the template name")
+	base := fs.String("base", "", "count due offsets from `DATE` (YYYY-MM-DD), default today")
+	vars := fs.String("vars", "", "comma separated `NAME=VALUE` values of the template variables")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	files, err := requireArgs(fs, 1, "template file")
+	if err != nil {
+		return err
+	}
+	if opts.ProjectID != "" && opts.Name != "" {
+		return usagef("projects instantiate: set either -project or -name")
+	}
+	if opts.BaseDate, err = parseBaseDate(fs.Name(), *base); err != nil {
+		return err
+	}
+	if opts.Variables, err = parseVars(fs.Name(), *vars); err != nil {
+		return err
+	}
+
+	in, err := openInput(e, files[0])
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmpl, err := blueprint.Read(in)
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	result, err := blueprint.Instantiate(client, tmpl, opts)
+	if result != nil && len(result.Tasks) > 0 {
+		if printErr := printList(e, result.Tasks, taskColumns); err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
+
+// parseBaseDate reads an optional local date.
+func parseBaseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, usagef("%s: invalid -base %q, want YYYY-MM-DD", name, value)
+	}
+	return t, nil
+}
+
+// parseVars reads comma separated NAME=VALUE pairs.
+func parseVars(name, value string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, pair := range splitList(value) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, usagef("%s: invalid variable %q, want NAME=VALUE", name, pair)
+		}
+		vars[strings.TrimSpace(k)] = v
+	}
+	return vars, nil
+}
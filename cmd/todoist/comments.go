@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+var commentActions = map[string]action{
+	"list":   {"-task ID | -project ID", "list comments", commentList},
+	"get":    {"ID", "show a comment", commentGet},
+	"add":    {"-task ID [-file PATH] CONTENT...", "add a comment, optionally with a file", commentAdd},
+	"update": {"-content TEXT ID", "edit a comment", commentUpdate},
+	"delete": {"ID...", "delete comments", commentDelete},
+}
+
+var commentColumns = []column[todoist.Comment]{
+	{"ID", func(c todoist.Comment) string { return c.ID }},
+	{"POSTED", func(c todoist.Comment) string { return c.PostedAt }},
+	{"CONTENT", func(c todoist.Comment) string { return c.Content }},
+	{"ATTACHMENT", func(c todoist.Comment) string {
+		if c.Attachment == nil {
+			return ""
+		}
+		return c.Attachment.FileName
+	}},
+}
+
+func commentList(e *env, args []string) error {
+	fs := e.newFlagSet("comments list")
+	task := fs.String("task", "", "task ID")
+	project := fs.String("project", "", "project ID")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if (*task == "") == (*project == "") {
+		return usagef("comments list: exactly one of -task and -project is required")
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	comments, err := client.GetComments(*task, *project)
+	if err != nil {
+		return err
+	}
+	return printList(e, comments, commentColumns)
+}
+
+func commentGet(e *env, args []string) error {
+	fs := e.newFlagSet("comments get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "comment ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	comment, err := client.GetComment(ids[0])
+	if err != nil {
+		return err
+	}
+	return printOne(e, comment, commentColumns)
+}
+
+func commentAdd(e *env, args []string) error {
+	fs := e.newFlagSet("comments add")
+	var params todoist.CommentParams
+	fs.StringVar(&params.TaskID, "task", "", "task ID")
+	fs.StringVar(&params.ProjectID, "project", "", "project ID")
+	fs.StringVar(&params.Content, "content", "", "comment text")
+	file := fs.String("file", "", "file to attach")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if params.Content == "" {
+		params.Content = joinArgs(fs)
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+
+	var comment *todoist.Comment
+	if *file == "" {
+		comment, err = client.CreateComment(params)
+	} else {
+		comment, err = addCommentWithFile(client, params, *file)
+	}
+	if err != nil {
+		return err
+	}
+	return printOne(e, comment, commentColumns)
+}
+
+func addCommentWithFile(client *todoist.TodoistClient, params todoist.CommentParams, path string) (*todoist.Comment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.CreateCommentWithFile(params, todoist.UploadParams{
+		File:     f,
+		FileName: filepath.Base(path),
+		Size:     info.Size(),
+	})
+}
+
+func commentUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("comments update")
+	var params todoist.CommentParams
+	fs.StringVar(&params.Content, "content", "", "new comment text")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "comment ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	comment, err := client.UpdateComment(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, comment, commentColumns)
+}
+
+func commentDelete(e *env, args []string) error {
+	return forEachID(e, "comments delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteComment(id)
+	})
+}
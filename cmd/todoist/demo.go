@@ -0,0 +1,61 @@
+//go:build demo
+
+package main
+
+import (
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/todoisttest"
+)
+
+func init() {
+	demoClient = startDemo
+}
+
+// startDemo starts an in-memory account with a small project.
+func startDemo() (*todoist.TodoistClient, func(), error) {
+	server := todoisttest.NewServer("demo")
+	client := server.Client()
+	if err := seedDemo(client); err != nil {
+		server.Close()
+		return nil, nil, err
+	}
+	return client, server.Close, nil
+}
+
+// seedDemo fills a demo account with a small project.
+func seedDemo(client *todoist.TodoistClient) error {
+	project, err := client.CreateProject(todoist.ProjectParams{Name: "Website", Color: todoist.ColorBlue})
+	if err != nil {
+		return err
+	}
+
+	sections := make(map[string]string)
+	for _, name := range []string{"Backlog", "Doing", "Review"} {
+		section, err := client.CreateSection(todoist.SectionParams{ProjectID: project.ID, Name: name})
+		if err != nil {
+			return err
+		}
+		sections[name] = section.ID
+	}
+
+	tasks := []todoist.TaskParams{
+		{Content: "Write landing page copy", SectionID: sections["Backlog"], Priority: todoist.PriorityP2, Labels: []string{"writing"}},
+		{Content: "Pick a color scheme", SectionID: sections["Backlog"], DueString: "tomorrow"},
+		{Content: "Set up CI", SectionID: sections["Doing"], Priority: todoist.PriorityP1, DueString: "today", Labels: []string{"dev"}},
+		{Content: "Review navigation PR", SectionID: sections["Review"], Labels: []string{"dev", "review"}},
+		{Content: "Renew domain", DueString: "today", Priority: todoist.PriorityP3},
+	}
+	for _, params := range tasks {
+		params.ProjectID = project.ID
+		if _, err := client.CreateTask(params); err != nil {
+			return err
+		}
+	}
+
+	ci, err := client.GetTasks("", sections["Doing"], "")
+	if err != nil || len(ci) == 0 {
+		return err
+	}
+	_, err = client.CreateComment(todoist.CommentParams{TaskID: ci[0].ID, Content: "Use the existing runner config as a starting point."})
+	return err
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"flag"
+	"strconv"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+var filterActions = map[string]action{
+	"list":    {"", "list saved filters", filterList},
+	"get":     {"ID", "show a saved filter", filterGet},
+	"add":     {"[flags] NAME", "save a filter", filterAdd},
+	"update":  {"[flags] ID", "update a saved filter", filterUpdate},
+	"delete":  {"ID...", "delete saved filters", filterDelete},
+	"reorder": {"ID...", "put saved filters in the given order", filterReorder},
+	"run":     {"ID", "list the tasks matching a saved filter", filterRun},
+}
+
+var filterColumns = []column[todoist.Filter]{
+	{"ID", func(f todoist.Filter) string { return f.ID }},
+	{"NAME", func(f todoist.Filter) string { return f.Name }},
+	{"QUERY", func(f todoist.Filter) string { return f.Query }},
+	{"COLOR", func(f todoist.Filter) string { return string(f.Color) }},
+	{"ORDER", func(f todoist.Filter) string { return strconv.Itoa(f.Order) }},
+	{"FAVORITE", func(f todoist.Filter) string { return yesNo(f.IsFavorite) }},
+}
+
+// filterFlags registers the flags mapping to FilterParams. Order and favorite are only sent when given.
+type filterFlags struct {
+	name, query, color string
+	order              *int
+	favorite           *bool
+}
+
+func newFilterFlags(fs *flag.FlagSet) *filterFlags {
+	f := &filterFlags{}
+	fs.StringVar(&f.name, "name", "", "filter name")
+	fs.StringVar(&f.query, "query", "", "filter `QUERY`, such as \"today & p1\"")
+	fs.StringVar(&f.color, "color", "", "color name, e.g. berry_red")
+	fs.Func("order", "position among the filters", func(v string) error {
+		n, err := strconv.Atoi(v)
+		f.order = &n
+		return err
+	})
+	fs.BoolFunc("favorite", "mark as favorite, or unmark with -favorite=false", func(v string) error {
+		b, err := strconv.ParseBool(v)
+		f.favorite = &b
+		return err
+	})
+	return f
+}
+
+func (f *filterFlags) params() (todoist.FilterParams, error) {
+	params := todoist.FilterParams{
+		Name:       f.name,
+		Query:      f.query,
+		Order:      f.order,
+		IsFavorite: f.favorite,
+	}
+	if f.color != "" {
+		color, err := todoist.ParseColor(f.color)
+		if err != nil {
+			return params, usagef("%v", err)
+		}
+		params.Color = color
+	}
+	return params, nil
+}
+
+func filterList(e *env, args []string) error {
+	fs := e.newFlagSet("filters list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	filters, err := client.GetFilters()
+	if err != nil {
+		return err
+	}
+	return printList(e, filters, filterColumns)
+}
+
+func filterGet(e *env, args []string) error {
+	fs := e.newFlagSet("filters get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "filter ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	filter, err := client.GetFilter(ids[0])
+	if err != nil {
+		return err
+	}
+	return printOne(e, filter, filterColumns)
+}
+
+func filterAdd(e *env, args []string) error {
+	fs := e.newFlagSet("filters add")
+	flags := newFilterFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if flags.name == "" {
+		flags.name = joinArgs(fs)
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	filter, err := client.CreateFilter(params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, filter, filterColumns)
+}
+
+func filterUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("filters update")
+	flags := newFilterFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "filter ID")
+	if err != nil {
+		return err
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	filter, err := client.UpdateFilter(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, filter, filterColumns)
+}
+
+func filterDelete(e *env, args []string) error {
+	return forEachID(e, "filters delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteFilter(id)
+	})
+}
+
+func filterReorder(e *env, args []string) error {
+	fs := e.newFlagSet("filters reorder")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "filter ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	if _, err := client.ReorderFilters(ids); err != nil {
+		return err
+	}
+	filters, err := client.GetFilters()
+	if err != nil {
+		return err
+	}
+	return printList(e, filters, filterColumns)
+}
+
+func filterRun(e *env, args []string) error {
+	fs := e.newFlagSet("filters run")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "filter ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	tasks, err := client.GetFilterTasks(ids[0])
+	if err != nil {
+		return err
+	}
+	return printList(e, tasks, taskColumns)
+}
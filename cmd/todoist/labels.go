@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"strconv"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+var labelActions = map[string]action{
+	"list":   {"", "list personal labels", labelList},
+	"get":    {"ID", "show a label", labelGet},
+	"add":    {"[flags] NAME", "create a label", labelAdd},
+	"update": {"[flags] ID", "update a label", labelUpdate},
+	"delete": {"ID...", "delete labels", labelDelete},
+}
+
+var labelColumns = []column[todoist.Label]{
+	{"ID", func(l todoist.Label) string { return l.ID }},
+	{"NAME", func(l todoist.Label) string { return l.Name }},
+	{"COLOR", func(l todoist.Label) string { return string(l.Color) }},
+	{"ORDER", func(l todoist.Label) string { return strconv.Itoa(l.Order) }},
+	{"FAVORITE", func(l todoist.Label) string { return yesNo(l.IsFavorite) }},
+}
+
+// labelFlags registers the flags mapping to LabelParams.
+type labelFlags struct {
+	name, color string
+	order       int
+	favorite    bool
+}
+
+func newLabelFlags(fs *flag.FlagSet) *labelFlags {
+	f := &labelFlags{}
+	fs.StringVar(&f.name, "name", "", "label name")
+	fs.StringVar(&f.color, "color", "", "color name, e.g. berry_red")
+	fs.IntVar(&f.order, "order", 0, "position among the labels")
+	fs.BoolVar(&f.favorite, "favorite", false, "mark as favorite")
+	return f
+}
+
+func (f *labelFlags) params() (todoist.LabelParams, error) {
+	params := todoist.LabelParams{
+		Name:       f.name,
+		Order:      f.order,
+		IsFavorite: f.favorite,
+	}
+	if f.color != "" {
+		color, err := todoist.ParseColor(f.color)
+		if err != nil {
+			return params, usagef("%v", err)
+		}
+		params.Color = color
+	}
+	return params, nil
+}
+
+func labelList(e *env, args []string) error {
+	fs := e.newFlagSet("labels list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	labels, err := client.GetLabels()
+	if err != nil {
+		return err
+	}
+	return printList(e, labels, labelColumns)
+}
+
+func labelGet(e *env, args []string) error {
+	fs := e.newFlagSet("labels get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "label ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	label, err := client.GetLabel(ids[0])
+	if err != nil {
+		return err
+	}
+	return printOne(e, label, labelColumns)
+}
+
+func labelAdd(e *env, args []string) error {
+	fs := e.newFlagSet("labels add")
+	flags := newLabelFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if flags.name == "" {
+		flags.name = joinArgs(fs)
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	label, err := client.CreateLabel(params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, label, labelColumns)
+}
+
+func labelUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("labels update")
+	flags := newLabelFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "label ID")
+	if err != nil {
+		return err
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	label, err := client.UpdateLabel(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, label, labelColumns)
+}
+
+func labelDelete(e *env, args []string) error {
+	return forEachID(e, "labels delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteLabel(id)
+	})
+}
@@ -0,0 +1,274 @@
+// Command todoist manages Todoist tasks, projects, sections, labels, saved filters, reminders and comments from the shell.
+//
+// Usage:
+//
+//	todoist [global flags] <resource> <action> [flags] [arguments]
+//
+// Run "todoist help" for the list of resources and actions.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/credentials"
+)
+
+// Exit codes returned by the command.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitInvalid     = 5
+	exitRateLimited = 6
+	exitServer      = 7
+	exitNetwork     = 8
+)
+
+// usageError reports a wrong invocation of the command.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// action is a subcommand of a resource.
+type action struct {
+	args string // Synopsis of flags and arguments
+	help string
+	run  func(env *env, args []string) error
+}
+
+// resources maps resource names and their actions to implementations.
+var resources = map[string]map[string]action{
+	"tasks":     taskActions,
+	"projects":  projectActions,
+	"sections":  sectionActions,
+	"labels":    labelActions,
+	"filters":   filterActions,
+	"reminders": reminderActions,
+	"comments":  commentActions,
+	"auth":      authActions,
+	"backup":    backupActions,
+	"spec":      specActions,
+}
+
+// env holds the global options and lazily created client of an invocation.
+type env struct {
+	stdout          io.Writer
+	stderr          io.Writer
+	format          string
+	profile         string
+	token           string
+	credentialsPath string
+	apiURL          string
+	client          *todoist.TodoistClient
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns its exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdout: stdout, stderr: stderr, format: formatTable}
+
+	global := flag.NewFlagSet("todoist", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Var(formatValue{&e.format}, "format", "output `FORMAT`: table, json or csv")
+	global.StringVar(&e.profile, "profile", os.Getenv("TODOIST_PROFILE"), "credential profile to use")
+	global.StringVar(&e.token, "token", "", "API token, overrides profiles and $TODOIST_TOKEN")
+	global.StringVar(&e.credentialsPath, "credentials", os.Getenv("TODOIST_CREDENTIALS"), "path of the encrypted credentials file")
+	global.StringVar(&e.apiURL, "api-url", os.Getenv("TODOIST_API_URL"), "base URL of the Todoist servers, for testing")
+	global.Usage = func() { printUsage(stderr) }
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	rest := global.Args()
+	if len(rest) == 0 || rest[0] == "help" {
+		printUsage(stderr)
+		if len(rest) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	if rest[0] == "tui" {
+		return finish(stderr, runTUI(e, rest[1:]))
+	}
+
+	actions, ok := resources[rest[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "todoist: unknown resource %q\n", rest[0])
+		return exitUsage
+	}
+	if len(rest) < 2 {
+		fmt.Fprintf(stderr, "todoist: missing action for %s\n", rest[0])
+		printResourceUsage(stderr, rest[0], actions)
+		return exitUsage
+	}
+	act, ok := actions[rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "todoist: unknown action %q for %s\n", rest[1], rest[0])
+		printResourceUsage(stderr, rest[0], actions)
+		return exitUsage
+	}
+
+	return finish(stderr, act.run(e, rest[2:]))
+}
+
+// finish reports the error of a command and returns the exit code.
+func finish(stderr io.Writer, err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "todoist: %v\n", err)
+	return exitCode(err)
+}
+
+// exitCode maps an error to the exit code of the command.
+func exitCode(err error) int {
+	var usageErr *usageError
+	var validationErrs todoist.ValidationErrors
+	var apiErr *todoist.APIError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.As(err, &validationErrs):
+		return exitInvalid
+	case errors.Is(err, errNoToken), errors.Is(err, credentials.ErrDecrypt), errors.Is(err, credentials.ErrProfileNotFound):
+		return exitAuth
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			return exitAuth
+		case apiErr.StatusCode == http.StatusNotFound:
+			return exitNotFound
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return exitRateLimited
+		case apiErr.StatusCode >= 500:
+			return exitServer
+		case apiErr.StatusCode >= 400:
+			return exitInvalid
+		}
+	case errors.As(err, &netErr):
+		return exitNetwork
+	}
+	return exitError
+}
+
+// newFlagSet creates the flag set of an action, which also accepts the -format flag.
+func (e *env) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Var(formatValue{&e.format}, "format", "output `FORMAT`: table, json or csv")
+	return fs
+}
+
+// parse parses the flags of an action, reporting bad flags as usage errors.
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{msg: err.Error()}
+	}
+	return nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: todoist [global flags] <resource> <action> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	fmt.Fprintln(w, "  -format table|json|csv   output format (default table)")
+	fmt.Fprintln(w, "  -profile NAME            credential profile, or $TODOIST_PROFILE")
+	fmt.Fprintln(w, "  -token TOKEN             API token, or $TODOIST_TOKEN")
+	fmt.Fprintln(w, "  -credentials PATH        credentials file, or $TODOIST_CREDENTIALS")
+	fmt.Fprintln(w, "  -api-url URL             Todoist server, or $TODOIST_API_URL")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Resources:")
+
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		printActions(tw, name, resources[name])
+	}
+	fmt.Fprintf(tw, "  tui [-demo]\t%s\n", "browse and triage tasks interactively")
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes: 0 ok, 1 error, 2 usage, 3 authentication, 4 not found,")
+	fmt.Fprintln(w, "5 invalid parameters, 6 rate limited, 7 server error, 8 network error")
+}
+
+func printResourceUsage(w io.Writer, resource string, actions map[string]action) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	printActions(tw, resource, actions)
+	tw.Flush()
+}
+
+// printActions writes one tab separated line per action, to be aligned by a tabwriter.
+func printActions(w io.Writer, resource string, actions map[string]action) {
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		act := actions[name]
+		fmt.Fprintf(w, "  %s %s %s\t%s\n", resource, name, act.args, act.help)
+	}
+}
+
+// splitList splits a comma separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// requireArgs checks the number of positional arguments of an action.
+func requireArgs(fs *flag.FlagSet, min int, what string) ([]string, error) {
+	if fs.NArg() < min {
+		return nil, usagef("%s: missing %s", fs.Name(), what)
+	}
+	return fs.Args(), nil
+}
+
+// joinArgs joins the positional arguments of an action, so names need no quoting.
+func joinArgs(fs *flag.FlagSet) string {
+	return strings.Join(fs.Args(), " ")
+}
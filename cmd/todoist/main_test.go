@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/todoisttest"
+)
+
+// runCLI runs the command against server and returns its exit code and output.
+func runCLI(server *todoisttest.Server, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	args = append([]string{"-token", server.Token, "-api-url", server.URL}, args...)
+	code = run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestTasks(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+
+	code, out, errOut := runCLI(server, "-format", "json", "tasks", "add", "-priority", "P1", "-labels", "home,errand", "Buy", "milk")
+	if code != exitOK {
+		t.Fatalf("tasks add: exit %d: %s", code, errOut)
+	}
+	var task todoist.Task
+	if err := json.Unmarshal([]byte(out), &task); err != nil {
+		t.Fatalf("tasks add output %q: %v", out, err)
+	}
+	if task.Content != "Buy milk" || task.Priority != todoist.PriorityP1 || strings.Join(task.Labels, ",") != "home,errand" {
+		t.Errorf("created task = %+v", task)
+	}
+
+	code, out, errOut = runCLI(server, "-format", "csv", "tasks", "list")
+	if code != exitOK {
+		t.Fatalf("tasks list: exit %d: %s", code, errOut)
+	}
+	if !strings.HasPrefix(out, "ID,PRIORITY,CONTENT") || !strings.Contains(out, task.ID+",P1,Buy milk") {
+		t.Errorf("tasks list output:\n%s", out)
+	}
+
+	if code, _, errOut := runCLI(server, "tasks", "close", task.ID); code != exitOK {
+		t.Fatalf("tasks close: exit %d: %s", code, errOut)
+	}
+	if tasks := server.Tasks(); len(tasks) != 1 || !tasks[0].IsCompleted {
+		t.Errorf("tasks after close = %+v", tasks)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"no resource", nil, exitUsage},
+		{"unknown resource", []string{"widgets", "list"}, exitUsage},
+		{"unknown action", []string{"tasks", "frobnicate"}, exitUsage},
+		{"missing argument", []string{"tasks", "get"}, exitUsage},
+		{"not found", []string{"tasks", "get", "999"}, exitNotFound},
+		{"invalid", []string{"projects", "add"}, exitInvalid},
+		{"numeric priority", []string{"tasks", "add", "-priority", "1", "Buy milk"}, exitUsage},
+		{"help", []string{"help"}, exitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _, errOut := runCLI(server, tt.args...); code != tt.want {
+				t.Errorf("exit %d, want %d: %s", code, tt.want, errOut)
+			}
+		})
+	}
+
+	// Unknown output formats are rejected before anything is sent, so nothing can be changed without being shown.
+	for _, args := range [][]string{
+		{"-format", "xml", "tasks", "add", "Buy milk"},
+		{"tasks", "add", "-format", "xml", "Buy milk"},
+	} {
+		if code, _, errOut := runCLI(server, args...); code != exitUsage {
+			t.Errorf("%v: exit %d, want %d: %s", args, code, exitUsage, errOut)
+		}
+	}
+	if tasks := server.Tasks(); len(tasks) != 0 {
+		t.Errorf("tasks after unknown formats = %+v, want none", tasks)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-token", "wrong", "-api-url", server.URL, "tasks", "list"}, &out, &errOut); code != exitAuth {
+		t.Errorf("wrong token: exit %d, want %d: %s", code, exitAuth, errOut.String())
+	}
+}
+
+func TestFilters(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+	client := server.Client()
+	for _, params := range []todoist.TaskParams{
+		{Content: "Pay rent", Priority: todoist.PriorityP1},
+		{Content: "Water plants"},
+	} {
+		if _, err := client.CreateTask(params); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	code, out, errOut := runCLI(server, "-format", "json", "filters", "add", "-query", "p1", "Urgent")
+	if code != exitOK {
+		t.Fatalf("filters add: exit %d: %s", code, errOut)
+	}
+	var f todoist.Filter
+	if err := json.Unmarshal([]byte(out), &f); err != nil {
+		t.Fatalf("filters add output %q: %v", out, err)
+	}
+
+	code, out, errOut = runCLI(server, "filters", "run", f.ID)
+	if code != exitOK {
+		t.Fatalf("filters run: exit %d: %s", code, errOut)
+	}
+	if !strings.Contains(out, "Pay rent") || strings.Contains(out, "Water plants") {
+		t.Errorf("filters run output:\n%s", out)
+	}
+}
+
+func TestSpecApplyPrune(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+	if _, err := server.Client().CreateProject(todoist.ProjectParams{Name: "Old"}); err != nil {
+		t.Fatal(err)
+	}
+	spec := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(spec, []byte("projects:\n  - name: Engineering\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	names := func() string {
+		var names []string
+		for _, project := range server.Projects() {
+			names = append(names, project.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	// Without -yes the plan is shown and nothing is changed.
+	code, out, errOut := runCLI(server, "spec", "apply", "-prune", spec)
+	if code != exitUsage {
+		t.Fatalf("spec apply -prune: exit %d, want %d: %s", code, exitUsage, errOut)
+	}
+	if !strings.Contains(out, `- project "Old"`) || !strings.Contains(errOut, "-yes") {
+		t.Errorf("spec apply -prune output:\n%s%s", out, errOut)
+	}
+	if got := names(); got != "Inbox,Old" {
+		t.Errorf("projects after refused apply = %s, want Inbox,Old", got)
+	}
+
+	if code, _, errOut := runCLI(server, "spec", "apply", "-prune", "-yes", spec); code != exitOK {
+		t.Fatalf("spec apply -prune -yes: exit %d: %s", code, errOut)
+	}
+	if got := names(); got != "Inbox,Engineering" {
+		t.Errorf("projects after apply = %s, want Inbox,Engineering", got)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// formatValue is the -format flag. It rejects unknown formats while the flags are parsed,
+// before any request is made.
+type formatValue struct {
+	format *string
+}
+
+func (v formatValue) String() string {
+	if v.format == nil {
+		return ""
+	}
+	return *v.format
+}
+
+func (v formatValue) Set(s string) error {
+	switch s {
+	case formatTable, formatJSON, formatCSV:
+		*v.format = s
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, want table, json or csv", s)
+}
+
+// column is a column of table and CSV output.
+type column[T any] struct {
+	header string
+	value  func(T) string
+}
+
+// printList writes items in the selected output format. JSON output contains the full objects,
+// tables and CSV only the given columns.
+func printList[T any](e *env, items []T, columns []column[T]) error {
+	if e.format == formatJSON {
+		if items == nil {
+			items = []T{}
+		}
+		return writeJSON(e.stdout, items)
+	}
+	return writeRows(e, items, columns)
+}
+
+// printOne writes a single item in the selected output format.
+func printOne[T any](e *env, item *T, columns []column[T]) error {
+	if e.format == formatJSON {
+		return writeJSON(e.stdout, item)
+	}
+	return writeRows(e, []T{*item}, columns)
+}
+
+func writeRows[T any](e *env, items []T, columns []column[T]) error {
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = make([]string, len(columns))
+		for j, col := range columns {
+			rows[i][j] = col.value(item)
+		}
+	}
+
+	switch e.format {
+	case formatTable:
+		tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			for i := range row {
+				row[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(row[i])
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case formatCSV:
+		w := csv.NewWriter(e.stdout)
+		w.Write(headers)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+	return usagef("unknown output format %q, want table, json or csv", e.format)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printResult reports the outcome of actions such as close or delete, which return no object.
+func printResult(e *env, verb string, ids []string) error {
+	type result struct {
+		ID     string `json:"id"`
+		Action string `json:"action"`
+	}
+
+	results := make([]result, len(ids))
+	for i, id := range ids {
+		results[i] = result{ID: id, Action: verb}
+	}
+	if e.format == formatTable {
+		for _, r := range results {
+			fmt.Fprintf(e.stdout, "%s %s\n", r.Action, r.ID)
+		}
+		return nil
+	}
+	return printList(e, results, []column[result]{
+		{"ID", func(r result) string { return r.ID }},
+		{"ACTION", func(r result) string { return r.Action }},
+	})
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+var projectActions = map[string]action{
+	"list":        {"", "list projects", projectList},
+	"get":         {"ID", "show a project", projectGet},
+	"add":         {"[flags] NAME...", "create a project", projectAdd},
+	"update":      {"[flags] ID", "update a project", projectUpdate},
+	"delete":      {"ID...", "delete projects", projectDelete},
+	"export":      {"[-markdown] [-o FILE] ID", "export a project as a Todoist CSV template or Markdown", projectExport},
+	"import":      {"[-markdown] [-project ID | -name NAME] [-dry-run] FILE", "import a CSV template or Markdown, - reads stdin", projectImport},
+	"capture":     {"[-o FILE] [-base DATE] [-vars NAME=VALUE,...] ID", "save a project as a reusable template", projectCapture},
+	"instantiate": {"[-project ID | -name NAME] [-base DATE] [-vars NAME=VALUE,...] FILE", "create a project from a template", projectInstantiate},
+}
+
+var projectColumns = []column[todoist.Project]{
+	{"ID", func(p todoist.Project) string { return p.ID }},
+	{"NAME", func(p todoist.Project) string { return p.Name }},
+	{"COLOR", func(p todoist.Project) string { return string(p.Color) }},
+	{"FAVORITE", func(p todoist.Project) string { return yesNo(p.IsFavorite) }},
+	{"VIEW", func(p todoist.Project) string { return string(p.ViewStyle) }},
+	{"PARENT", func(p todoist.Project) string {
+		if p.ParentID == nil {
+			return ""
+		}
+		return *p.ParentID
+	}},
+}
+
+// projectFlags registers the flags mapping to ProjectParams.
+type projectFlags struct {
+	name, parent, color, view string
+	favorite                  bool
+}
+
+func newProjectFlags(fs *flag.FlagSet) *projectFlags {
+	f := &projectFlags{}
+	fs.StringVar(&f.name, "name", "", "project name")
+	fs.StringVar(&f.parent, "parent", "", "parent project ID")
+	fs.StringVar(&f.color, "color", "", "color name, e.g. berry_red")
+	fs.StringVar(&f.view, "view", "", "view style, list or board")
+	fs.BoolVar(&f.favorite, "favorite", false, "mark as favorite")
+	return f
+}
+
+func (f *projectFlags) params() (todoist.ProjectParams, error) {
+	params := todoist.ProjectParams{
+		Name:       f.name,
+		ParentID:   f.parent,
+		IsFavorite: f.favorite,
+		ViewStyle:  todoist.ViewStyle(f.view),
+	}
+	if f.color != "" {
+		color, err := todoist.ParseColor(f.color)
+		if err != nil {
+			return params, usagef("%v", err)
+		}
+		params.Color = color
+	}
+	return params, nil
+}
+
+func projectList(e *env, args []string) error {
+	fs := e.newFlagSet("projects list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	projects, err := client.GetProjects()
+	if err != nil {
+		return err
+	}
+	return printList(e, projects, projectColumns)
+}
+
+func projectGet(e *env, args []string) error {
+	fs := e.newFlagSet("projects get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "project ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	project, err := client.GetProject(ids[0])
+	if err != nil {
+		return err
+	}
+	return printOne(e, project, projectColumns)
+}
+
+func projectAdd(e *env, args []string) error {
+	fs := e.newFlagSet("projects add")
+	flags := newProjectFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if flags.name == "" {
+		flags.name = joinArgs(fs)
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	project, err := client.CreateProject(params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, project, projectColumns)
+}
+
+func projectUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("projects update")
+	flags := newProjectFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "project ID")
+	if err != nil {
+		return err
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	project, err := client.UpdateProject(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, project, projectColumns)
+}
+
+func projectDelete(e *env, args []string) error {
+	return forEachID(e, "projects delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteProject(id)
+	})
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+var reminderActions = map[string]action{
+	"list":   {"[-task ID]", "list reminders", reminderList},
+	"add":    {"[flags] TASK_ID", "add a reminder to a task", reminderAdd},
+	"update": {"[flags] ID", "update a reminder", reminderUpdate},
+	"delete": {"ID...", "delete reminders", reminderDelete},
+}
+
+var reminderColumns = []column[todoist.Reminder]{
+	{"ID", func(r todoist.Reminder) string { return r.ID }},
+	{"TASK", func(r todoist.Reminder) string { return r.TaskID }},
+	{"TYPE", func(r todoist.Reminder) string { return string(r.Type) }},
+	{"WHEN", reminderWhen},
+}
+
+// reminderWhen describes when a reminder fires.
+func reminderWhen(r todoist.Reminder) string {
+	switch r.Type {
+	case todoist.ReminderRelative:
+		return fmt.Sprintf("%d min before due", r.MinuteOffset)
+	case todoist.ReminderAbsolute:
+		if r.Due != nil {
+			return r.Due.Date
+		}
+	case todoist.ReminderLocation:
+		if r.Trigger == todoist.TriggerOnLeave {
+			return "leaving " + r.Name
+		}
+		return "arriving at " + r.Name
+	}
+	return ""
+}
+
+// reminderFlags registers the flags mapping to ReminderParams. The reminder type follows from
+// the flags given.
+type reminderFlags struct {
+	minutes                      int
+	at, notify                   string
+	location, lat, long, trigger string
+	radius                       int
+}
+
+func newReminderFlags(fs *flag.FlagSet) *reminderFlags {
+	f := &reminderFlags{}
+	fs.IntVar(&f.minutes, "minutes", -1, "remind this many `MINUTES` before the task is due")
+	fs.StringVar(&f.at, "at", "", "remind at a date and time in RFC 3339")
+	fs.StringVar(&f.location, "location", "", "remind at a place of this name")
+	fs.StringVar(&f.lat, "lat", "", "latitude of the place")
+	fs.StringVar(&f.long, "long", "", "longitude of the place")
+	fs.StringVar(&f.trigger, "trigger", "", "remind on_enter or on_leave of the place")
+	fs.IntVar(&f.radius, "radius", 0, "radius of the place in meters")
+	fs.StringVar(&f.notify, "notify", "", "user ID to notify")
+	return f
+}
+
+func (f *reminderFlags) params() (todoist.ReminderParams, error) {
+	params := todoist.ReminderParams{
+		DueDatetime: f.at,
+		NotifyUID:   f.notify,
+		Name:        f.location,
+		Latitude:    f.lat,
+		Longitude:   f.long,
+		Trigger:     todoist.LocationTrigger(f.trigger),
+		Radius:      f.radius,
+	}
+
+	var types []todoist.ReminderType
+	if f.minutes >= 0 {
+		params.MinuteOffset = &f.minutes
+		types = append(types, todoist.ReminderRelative)
+	}
+	if f.at != "" {
+		types = append(types, todoist.ReminderAbsolute)
+	}
+	if f.location != "" || f.lat != "" || f.long != "" || f.trigger != "" || f.radius != 0 {
+		types = append(types, todoist.ReminderLocation)
+	}
+	switch len(types) {
+	case 0:
+	case 1:
+		params.Type = types[0]
+	default:
+		return params, usagef("only one of -minutes, -at and the location flags may be given")
+	}
+	if err := params.Validate(); err != nil {
+		return params, usagef("%v", err)
+	}
+	return params, nil
+}
+
+func reminderList(e *env, args []string) error {
+	fs := e.newFlagSet("reminders list")
+	task := fs.String("task", "", "only reminders of this task ID")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	reminders, err := client.GetReminders(*task)
+	if err != nil {
+		return err
+	}
+	return printList(e, reminders, reminderColumns)
+}
+
+func reminderAdd(e *env, args []string) error {
+	fs := e.newFlagSet("reminders add")
+	flags := newReminderFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "task ID")
+	if err != nil {
+		return err
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+	if params.Type == "" {
+		return usagef("reminders add: one of -minutes, -at or -location is required")
+	}
+	params.TaskID = ids[0]
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	reminder, err := client.AddReminder(params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, reminder, reminderColumns)
+}
+
+func reminderUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("reminders update")
+	flags := newReminderFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "reminder ID")
+	if err != nil {
+		return err
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	reminder, err := client.UpdateReminder(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, reminder, reminderColumns)
+}
+
+func reminderDelete(e *env, args []string) error {
+	return forEachID(e, "reminders delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteReminder(id)
+	})
+}
+
+// parseMinutes reads a comma separated list of minute offsets, as given to "tasks add -remind".
+func parseMinutes(list string) ([]int, error) {
+	var minutes []int
+	for _, s := range splitList(list) {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, usagef("invalid reminder offset %q, want minutes", s)
+		}
+		minutes = append(minutes, n)
+	}
+	return minutes, nil
+}
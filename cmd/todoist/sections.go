@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+var sectionActions = map[string]action{
+	"list":   {"[-project ID]", "list sections", sectionList},
+	"get":    {"ID", "show a section", sectionGet},
+	"add":    {"-project ID [-order N] NAME...", "create a section", sectionAdd},
+	"update": {"-name NAME ID", "rename a section", sectionUpdate},
+	"delete": {"ID...", "delete sections", sectionDelete},
+}
+
+var sectionColumns = []column[todoist.Section]{
+	{"ID", func(s todoist.Section) string { return s.ID }},
+	{"NAME", func(s todoist.Section) string { return s.Name }},
+	{"PROJECT", func(s todoist.Section) string { return s.ProjectID }},
+	{"ORDER", func(s todoist.Section) string { return strconv.Itoa(s.Order) }},
+}
+
+func sectionList(e *env, args []string) error {
+	fs := e.newFlagSet("sections list")
+	project := fs.String("project", "", "only sections of this project ID")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	sections, err := client.GetSections(*project)
+	if err != nil {
+		return err
+	}
+	return printList(e, sections, sectionColumns)
+}
+
+func sectionGet(e *env, args []string) error {
+	fs := e.newFlagSet("sections get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "section ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	section, err := client.GetSection(ids[0])
+	if err != nil {
+		return err
+	}
+	return printOne(e, section, sectionColumns)
+}
+
+func sectionAdd(e *env, args []string) error {
+	fs := e.newFlagSet("sections add")
+	var params todoist.SectionParams
+	fs.StringVar(&params.ProjectID, "project", "", "project ID")
+	fs.StringVar(&params.Name, "name", "", "section name")
+	fs.IntVar(&params.Order, "order", 0, "position among the project's sections")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if params.Name == "" {
+		params.Name = joinArgs(fs)
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	section, err := client.CreateSection(params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, section, sectionColumns)
+}
+
+func sectionUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("sections update")
+	var params todoist.SectionParams
+	fs.StringVar(&params.Name, "name", "", "new section name")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "section ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	section, err := client.UpdateSection(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, section, sectionColumns)
+}
+
+func sectionDelete(e *env, args []string) error {
+	return forEachID(e, "sections delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteSection(id)
+	})
+}
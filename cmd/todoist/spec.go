@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/felixschmelzer/todoist-go/plan"
+)
+
+var specActions = map[string]action{
+	"plan":  {"[-prune] FILE", "show the changes bringing the account to a YAML or JSON spec", specPlan},
+	"apply": {"[-prune [-yes]] FILE", "apply the changes bringing the account to a spec, deletions only with -yes", specApply},
+}
+
+var actionColumns = []column[plan.Action]{
+	{"ACTION", func(a plan.Action) string { return string(a.Action) }},
+	{"TYPE", func(a plan.Action) string { return a.Type }},
+	{"PROJECT", func(a plan.Action) string { return a.Project }},
+	{"NAME", func(a plan.Action) string { return a.Name }},
+	{"ID", func(a plan.Action) string { return a.ID }},
+}
+
+func specPlan(e *env, args []string) error {
+	p, err := computePlan(e, e.newFlagSet("spec plan"), args)
+	if err != nil {
+		return err
+	}
+	return printPlan(e, p)
+}
+
+func specApply(e *env, args []string) error {
+	fs := e.newFlagSet("spec apply")
+	yes := fs.Bool("yes", false, "carry out the deletions of a pruning plan")
+	p, err := computePlan(e, fs, args)
+	if err != nil {
+		return err
+	}
+	// Deletions cannot be undone, so they need to be reviewed first.
+	if n := p.Count(plan.Delete); n > 0 && !*yes {
+		if err := printPlan(e, p); err != nil {
+			return err
+		}
+		return usagef("spec apply: the plan deletes %d records, review it and run again with -yes", n)
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	err = plan.Apply(client, p)
+	if printErr := printPlan(e, p); err == nil {
+		err = printErr
+	}
+	return err
+}
+
+// computePlan parses the flags of a spec command, reads the spec named in args and diffs it
+// against the account.
+func computePlan(e *env, fs *flag.FlagSet, args []string) (*plan.Plan, error) {
+	opts := plan.Options{}
+	fs.BoolVar(&opts.Prune, "prune", false, "delete projects, sections and labels missing from the spec")
+	if err := parse(fs, args); err != nil {
+		return nil, err
+	}
+	files, err := requireArgs(fs, 1, "spec file")
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := openInput(e, files[0])
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	spec, err := plan.Load(in)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return nil, err
+	}
+	return plan.Compute(client, spec, opts)
+}
+
+// printPlan writes a plan, as a readable diff in table format.
+func printPlan(e *env, p *plan.Plan) error {
+	switch e.format {
+	case formatTable:
+		_, err := fmt.Fprint(e.stdout, p)
+		return err
+	case formatJSON:
+		return writeJSON(e.stdout, p)
+	}
+	return printList(e, p.Actions, actionColumns)
+}
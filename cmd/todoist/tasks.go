@@ -0,0 +1,296 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/filter"
+)
+
+var taskActions = map[string]action{
+	"list":   {"[-project ID] [-section ID] [-label NAME] [-filter QUERY] [-sort ORDER]", "list active tasks", taskList},
+	"get":    {"ID", "show a task", taskGet},
+	"add":    {"[flags] CONTENT...", "create a task", taskAdd},
+	"update": {"[flags] ID", "update a task", taskUpdate},
+	"close":  {"ID...", "complete tasks", taskClose},
+	"reopen": {"ID...", "reopen completed tasks", taskReopen},
+	"delete": {"ID...", "delete tasks", taskDelete},
+}
+
+var taskColumns = []column[todoist.Task]{
+	{"ID", func(t todoist.Task) string { return t.ID }},
+	{"PRIORITY", func(t todoist.Task) string { return t.Priority.String() }},
+	{"CONTENT", func(t todoist.Task) string { return t.Content }},
+	{"DUE", func(t todoist.Task) string { return taskDue(t.Due) }},
+	{"LABELS", func(t todoist.Task) string { return strings.Join(t.Labels, ",") }},
+	{"PROJECT", func(t todoist.Task) string { return t.ProjectID }},
+	{"SECTION", func(t todoist.Task) string { return t.SectionID }},
+}
+
+func taskDue(due *todoist.TaskDue) string {
+	switch {
+	case due == nil:
+		return ""
+	case due.Datetime != "":
+		return due.Datetime
+	}
+	return due.Date
+}
+
+// taskFlags registers the flags mapping to TaskParams.
+type taskFlags struct {
+	content, description            string
+	project, section, parent        string
+	priority, labels                string
+	due, dueDate, dueDatetime, lang string
+	assignee                        string
+	duration                        time.Duration
+	durationDays                    int
+}
+
+func newTaskFlags(fs *flag.FlagSet) *taskFlags {
+	f := &taskFlags{}
+	fs.StringVar(&f.content, "content", "", "task content")
+	fs.StringVar(&f.description, "description", "", "task description")
+	fs.StringVar(&f.project, "project", "", "project ID")
+	fs.StringVar(&f.section, "section", "", "section ID")
+	fs.StringVar(&f.parent, "parent", "", "parent task ID")
+	fs.StringVar(&f.priority, "priority", "", "priority, P1 to P4")
+	fs.StringVar(&f.labels, "labels", "", "comma separated label names")
+	fs.StringVar(&f.due, "due", "", "due date in natural language, e.g. \"tomorrow 9am\"")
+	fs.StringVar(&f.dueDate, "due-date", "", "due date as YYYY-MM-DD")
+	fs.StringVar(&f.dueDatetime, "due-datetime", "", "due date and time in RFC 3339")
+	fs.StringVar(&f.lang, "due-lang", "", "language of -due")
+	fs.StringVar(&f.assignee, "assignee", "", "user ID of the assignee")
+	fs.DurationVar(&f.duration, "duration", 0, "estimated duration, e.g. 90m")
+	fs.IntVar(&f.durationDays, "duration-days", 0, "estimated duration in days")
+	return f
+}
+
+func (f *taskFlags) params() (todoist.TaskParams, error) {
+	params := todoist.TaskParams{
+		Content:     f.content,
+		Description: f.description,
+		ProjectID:   f.project,
+		SectionID:   f.section,
+		ParentID:    f.parent,
+		Labels:      splitList(f.labels),
+		DueString:   f.due,
+		DueDate:     f.dueDate,
+		DueDatetime: f.dueDatetime,
+		DueLang:     f.lang,
+		AssigneeID:  f.assignee,
+	}
+
+	if f.priority != "" {
+		priority, err := todoist.ParsePriority(f.priority)
+		if err != nil {
+			return params, usagef("%v", err)
+		}
+		params.Priority = priority
+	}
+
+	switch {
+	case f.duration != 0 && f.durationDays != 0:
+		return params, usagef("-duration and -duration-days are mutually exclusive")
+	case f.duration != 0:
+		params = params.WithDuration(f.duration)
+	case f.durationDays != 0:
+		params = params.WithDurationDays(f.durationDays)
+	}
+
+	return params, nil
+}
+
+func taskList(e *env, args []string) error {
+	fs := e.newFlagSet("tasks list")
+	project := fs.String("project", "", "only tasks of this project ID")
+	section := fs.String("section", "", "only tasks of this section ID")
+	label := fs.String("label", "", "only tasks with this label")
+	query := fs.String("filter", "", "only tasks matching a Todoist filter `QUERY`, such as \"today & p1\"")
+	sortBy := fs.String("sort", "", "sort by urgency or priority")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	var f *filter.Filter
+	if *query != "" {
+		var err error
+		if f, err = filter.Parse(*query); err != nil {
+			return usagef("tasks list: %v", err)
+		}
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	tasks, err := client.GetTasks(*project, *section, *label)
+	if err != nil {
+		return err
+	}
+	if f != nil {
+		projects, err := client.GetProjects()
+		if err != nil {
+			return err
+		}
+		sections, err := client.GetSections("")
+		if err != nil {
+			return err
+		}
+		tasks = f.Apply(tasks, &filter.Env{Projects: projects, Sections: sections})
+	}
+
+	switch *sortBy {
+	case "":
+	case "urgency":
+		todoist.SortTasksByUrgency(tasks)
+	case "priority":
+		todoist.SortTasksByPriority(tasks)
+	default:
+		return usagef("tasks list: unknown sort order %q", *sortBy)
+	}
+
+	return printList(e, tasks, taskColumns)
+}
+
+func taskGet(e *env, args []string) error {
+	fs := e.newFlagSet("tasks get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "task ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	task, err := client.GetTask(ids[0])
+	if err != nil {
+		return err
+	}
+	return printOne(e, task, taskColumns)
+}
+
+func taskAdd(e *env, args []string) error {
+	fs := e.newFlagSet("tasks add")
+	flags := newTaskFlags(fs)
+	remind := fs.String("remind", "", "comma separated `MINUTES` before the due time to be reminded at")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if flags.content == "" {
+		flags.content = joinArgs(fs)
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+	minutes, err := parseMinutes(*remind)
+	if err != nil {
+		return err
+	}
+	reminders := make([]todoist.ReminderParams, len(minutes))
+	for i, m := range minutes {
+		reminders[i] = todoist.ReminderParams{Type: todoist.ReminderRelative, MinuteOffset: &m}
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	task, _, err := client.CreateTaskWithReminders(params, reminders...)
+	if err != nil {
+		if task != nil {
+			// The task exists even though its reminders failed; show it so it is not added twice.
+			if printErr := printOne(e, task, taskColumns); printErr != nil {
+				return printErr
+			}
+			return fmt.Errorf("task %s was created, but adding its reminders failed: %w", task.ID, err)
+		}
+		return err
+	}
+	return printOne(e, task, taskColumns)
+}
+
+func taskUpdate(e *env, args []string) error {
+	fs := e.newFlagSet("tasks update")
+	flags := newTaskFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "task ID")
+	if err != nil {
+		return err
+	}
+
+	params, err := flags.params()
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	task, err := client.UpdateTask(ids[0], params)
+	if err != nil {
+		return err
+	}
+	return printOne(e, task, taskColumns)
+}
+
+func taskClose(e *env, args []string) error {
+	return forEachID(e, "tasks close", args, "closed", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.CloseTask(id)
+	})
+}
+
+func taskReopen(e *env, args []string) error {
+	return forEachID(e, "tasks reopen", args, "reopened", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.ReopenTask(id)
+	})
+}
+
+func taskDelete(e *env, args []string) error {
+	return forEachID(e, "tasks delete", args, "deleted", func(c *todoist.TodoistClient, id string) (bool, error) {
+		return c.DeleteTask(id)
+	})
+}
+
+// forEachID runs an action without a result object, such as delete, for every ID argument.
+// It stops at the first failure; the IDs handled so far are still reported.
+func forEachID(e *env, name string, args []string, verb string, fn func(*todoist.TodoistClient, string) (bool, error)) error {
+	fs := e.newFlagSet(name)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+
+	var done []string
+	for _, id := range ids {
+		if _, err = fn(client, id); err != nil {
+			break
+		}
+		done = append(done, id)
+	}
+
+	if printErr := printResult(e, verb, done); printErr != nil && err == nil {
+		err = printErr
+	}
+	return err
+}
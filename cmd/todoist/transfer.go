@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/csvtemplate"
+	"github.com/felixschmelzer/todoist-go/markdown"
+)
+
+var changeColumns = []column[csvtemplate.Change]{
+	{"LINE", func(c csvtemplate.Change) string { return strconv.Itoa(c.Line) }},
+	{"ACTION", func(c csvtemplate.Change) string { return c.Action }},
+	{"TYPE", func(c csvtemplate.Change) string { return c.Type }},
+	{"NAME", func(c csvtemplate.Change) string { return c.Name }},
+	{"ID", func(c csvtemplate.Change) string { return c.ID }},
+}
+
+func projectExport(e *env, args []string) error {
+	fs := e.newFlagSet("projects export")
+	output := fs.String("o", "", "write to `FILE` instead of stdout")
+	asMarkdown := fs.Bool("markdown", false, "export a Markdown checklist instead of a CSV template")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	ids, err := requireArgs(fs, 1, "project ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	return writeOutput(e, *output, func(w io.Writer) error {
+		if *asMarkdown {
+			return markdown.Export(client, ids[0], w)
+		}
+		return csvtemplate.Export(client, ids[0], w)
+	})
+}
+
+func projectImport(e *env, args []string) error {
+	fs := e.newFlagSet("projects import")
+	opts := csvtemplate.ImportOptions{}
+	fs.StringVar(&opts.ProjectID, "project", "", "import into the existing project `ID`")
+	fs.StringVar(&opts.ProjectName, "name", "", "create a new project named `NAME`")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "report what would be created without changing anything")
+	fs.BoolVar(&opts.Assignees, "assignees", false, "assign tasks to the users in the RESPONSIBLE column")
+	asMarkdown := fs.Bool("markdown", false, "import a Markdown checklist instead of a CSV template")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	files, err := requireArgs(fs, 1, "file")
+	if err != nil {
+		return err
+	}
+	switch {
+	case opts.ProjectID != "" && opts.ProjectName != "":
+		return usagef("projects import: set either -project or -name")
+	case *asMarkdown && (opts.DryRun || opts.Assignees):
+		return usagef("projects import: -dry-run and -assignees only apply to CSV templates")
+	case !*asMarkdown && opts.ProjectID == "" && opts.ProjectName == "":
+		return usagef("projects import: set either -project or -name")
+	}
+
+	in, err := openInput(e, files[0])
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	if *asMarkdown {
+		return importMarkdown(e, client, in, markdown.ImportOptions{ProjectID: opts.ProjectID, ProjectName: opts.ProjectName})
+	}
+
+	report, err := csvtemplate.Import(client, in, opts)
+	if report != nil {
+		if printErr := printReport(e, report); err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
+
+// importMarkdown imports a checklist and lists the created tasks.
+func importMarkdown(e *env, client *todoist.TodoistClient, in io.Reader, opts markdown.ImportOptions) error {
+	result, err := markdown.Import(client, in, opts)
+	if result != nil && len(result.Tasks) > 0 {
+		if printErr := printList(e, result.Tasks, taskColumns); err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
+
+// printReport writes an import report, as a readable summary in table format.
+func printReport(e *env, report *csvtemplate.Report) error {
+	switch e.format {
+	case formatTable:
+		_, err := fmt.Fprint(e.stdout, report)
+		return err
+	case formatJSON:
+		return writeJSON(e.stdout, report)
+	}
+	return printList(e, report.Changes, changeColumns)
+}
+
+// openInput opens a file to read, or stdin for "-".
+func openInput(e *env, path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// writeOutput calls write with a file created at path, or stdout when path is empty.
+// A partly written file is removed when write fails.
+func writeOutput(e *env, path string, write func(io.Writer) error) error {
+	if path == "" {
+		return write(e.stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
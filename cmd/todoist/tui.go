@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/tui"
+)
+
+// demoClient starts an in-memory demo account and returns a client for it and a function
+// stopping it. It is only set in builds with the demo tag, which keeps the fake server out
+// of the regular binary.
+var demoClient func() (*todoist.TodoistClient, func(), error)
+
+// runTUI starts the terminal UI, against the user's account or an in-memory demo account.
+func runTUI(e *env, args []string) error {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	demo := fs.Bool("demo", false, "use an in-memory demo account instead of yours (needs -tags demo)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if *demo {
+		if demoClient == nil {
+			return usagef("tui: -demo is only available in builds with -tags demo")
+		}
+		client, stop, err := demoClient()
+		if err != nil {
+			return err
+		}
+		defer stop()
+		return tui.RunTerminal(context.Background(), client)
+	}
+
+	client, err := e.Client()
+	if err != nil {
+		return err
+	}
+	return tui.RunTerminal(context.Background(), client)
+}
@@ -0,0 +1,158 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Color is one of the named colors Todoist accepts for projects, labels and filters.
+type Color string
+
+const (
+	ColorBerryRed   Color = "berry_red"
+	ColorRed        Color = "red"
+	ColorOrange     Color = "orange"
+	ColorYellow     Color = "yellow"
+	ColorOliveGreen Color = "olive_green"
+	ColorLimeGreen  Color = "lime_green"
+	ColorGreen      Color = "green"
+	ColorMintGreen  Color = "mint_green"
+	ColorTeal       Color = "teal"
+	ColorSkyBlue    Color = "sky_blue"
+	ColorLightBlue  Color = "light_blue"
+	ColorBlue       Color = "blue"
+	ColorGrape      Color = "grape"
+	ColorViolet     Color = "violet"
+	ColorLavender   Color = "lavender"
+	ColorMagenta    Color = "magenta"
+	ColorSalmon     Color = "salmon"
+	ColorCharcoal   Color = "charcoal"
+	ColorGrey       Color = "grey"
+	ColorTaupe      Color = "taupe"
+)
+
+// colorInfo holds the details of a palette entry.
+type colorInfo struct {
+	id          int
+	hex         string
+	displayName string
+}
+
+// palette maps each color to its legacy numeric ID, hex value and display name.
+var palette = map[Color]colorInfo{
+	ColorBerryRed:   {30, "#b8256f", "Berry Red"},
+	ColorRed:        {31, "#db4035", "Red"},
+	ColorOrange:     {32, "#ff9933", "Orange"},
+	ColorYellow:     {33, "#fad000", "Yellow"},
+	ColorOliveGreen: {34, "#afb83b", "Olive Green"},
+	ColorLimeGreen:  {35, "#7ecc49", "Lime Green"},
+	ColorGreen:      {36, "#299438", "Green"},
+	ColorMintGreen:  {37, "#6accbc", "Mint Green"},
+	ColorTeal:       {38, "#158fad", "Teal"},
+	ColorSkyBlue:    {39, "#14aaf5", "Sky Blue"},
+	ColorLightBlue:  {40, "#96c3eb", "Light Blue"},
+	ColorBlue:       {41, "#4073ff", "Blue"},
+	ColorGrape:      {42, "#884dff", "Grape"},
+	ColorViolet:     {43, "#af38eb", "Violet"},
+	ColorLavender:   {44, "#eb96eb", "Lavender"},
+	ColorMagenta:    {45, "#e05194", "Magenta"},
+	ColorSalmon:     {46, "#ff8d85", "Salmon"},
+	ColorCharcoal:   {47, "#808080", "Charcoal"},
+	ColorGrey:       {48, "#b8b8b8", "Grey"},
+	ColorTaupe:      {49, "#ccac93", "Taupe"},
+}
+
+// Colors returns the full Todoist palette in the order shown by the Todoist apps.
+func Colors() []Color {
+	colors := make([]Color, 0, len(palette))
+	for c := range palette {
+		colors = append(colors, c)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		return palette[colors[i]].id < palette[colors[j]].id
+	})
+	return colors
+}
+
+// IsValid reports whether c is part of the Todoist palette.
+func (c Color) IsValid() bool {
+	_, ok := palette[c]
+	return ok
+}
+
+// Hex returns the hex value of the color, e.g. "#b8256f", or "" if the color is unknown.
+func (c Color) Hex() string {
+	return palette[c].hex
+}
+
+// DisplayName returns the name shown in the Todoist apps, e.g. "Berry Red", or "" if the color is unknown.
+func (c Color) DisplayName() string {
+	return palette[c].displayName
+}
+
+// ID returns the legacy numeric ID of the color, or 0 if the color is unknown.
+func (c Color) ID() int {
+	return palette[c].id
+}
+
+// ColorFromID returns the color with the given legacy numeric ID.
+func ColorFromID(id int) (Color, error) {
+	for c, info := range palette {
+		if info.id == id {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("unknown color ID %d", id)
+}
+
+// ParseColor parses a color name ("berry_red"), display name ("Berry Red"), hex value or legacy numeric ID.
+func ParseColor(s string) (Color, error) {
+	s = strings.TrimSpace(s)
+
+	if id, err := strconv.Atoi(s); err == nil {
+		return ColorFromID(id)
+	}
+
+	normalized := strings.ToLower(strings.ReplaceAll(s, " ", "_"))
+	if Color(normalized).IsValid() {
+		return Color(normalized), nil
+	}
+	for c, info := range palette {
+		if strings.EqualFold(info.hex, s) {
+			return c, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown color %q", s)
+}
+
+// UnmarshalJSON accepts color names as well as the legacy numeric color IDs.
+// Unknown names and IDs are kept as they are, IDs in their decimal form, so new colors added by
+// Todoist do not break decoding.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		var id int
+		if err := json.Unmarshal(data, &id); err != nil {
+			return err
+		}
+		parsed, err := ColorFromID(id)
+		if err != nil {
+			parsed = Color(strconv.Itoa(id))
+		}
+		*c = parsed
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	*c = Color(name)
+	return nil
+}
@@ -0,0 +1,150 @@
+package todoist
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Command is a write command of the Sync API, such as "item_move".
+type Command struct {
+	Type   string      `json:"type"`
+	UUID   string      `json:"uuid"`
+	TempID string      `json:"temp_id,omitempty"`
+	Args   interface{} `json:"args"`
+}
+
+// NewCommand creates a command of the given type with a random UUID.
+func NewCommand(commandType string, args interface{}) Command {
+	return Command{Type: commandType, UUID: newUUID(), Args: args}
+}
+
+// CommandResult is the outcome of a successful batch of commands.
+type CommandResult struct {
+	SyncToken     string
+	TempIDMapping map[string]string // Temporary IDs of created objects mapped to their real IDs
+}
+
+// CommandError is returned when the Sync API rejects a command.
+// It unwraps to an APIError carrying the HTTP status the API reported for the command.
+type CommandError struct {
+	Command  Command
+	Code     int    `json:"error_code"`
+	Message  string `json:"error"`
+	HTTPCode int    `json:"http_code"`
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("command %s failed: %s (error code %d)", e.Command.Type, e.Message, e.Code)
+}
+
+func (e *CommandError) Unwrap() error {
+	return &APIError{StatusCode: e.HTTPCode, Message: e.Message}
+}
+
+// ExecuteCommands sends a batch of commands to the Sync API. If a command is rejected,
+// a *CommandError for the first rejected command is returned; the others may have been applied.
+func (c *TodoistClient) ExecuteCommands(commands ...Command) (*CommandResult, error) {
+	return c.ExecuteCommandsContext(context.Background(), commands...)
+}
+
+// ExecuteCommandsContext is like ExecuteCommands but uses ctx for the request.
+func (c *TodoistClient) ExecuteCommandsContext(ctx context.Context, commands ...Command) (*CommandResult, error) {
+	if len(commands) == 0 {
+		return nil, errors.New("no commands given")
+	}
+
+	encoded, err := json.Marshal(commands)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("commands", string(encoded))
+
+	resp, err := sendForm(ctx, c.HTTPClient, fmt.Sprintf("%s/sync", c.SyncBaseURL), c.tokenSource(), form)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		SyncToken     string                     `json:"sync_token"`
+		SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+		TempIDMapping map[string]string          `json:"temp_id_mapping"`
+	}
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	for _, cmd := range commands {
+		status, ok := result.SyncStatus[cmd.UUID]
+		if !ok || string(status) == `"ok"` {
+			continue
+		}
+		cmdErr := &CommandError{Command: cmd}
+		if err := json.Unmarshal(status, cmdErr); err != nil {
+			return nil, fmt.Errorf("invalid status of command %s: %s", cmd.Type, status)
+		}
+		return nil, cmdErr
+	}
+
+	return &CommandResult{SyncToken: result.SyncToken, TempIDMapping: result.TempIDMapping}, nil
+}
+
+// MoveTask moves a task to another project, section or parent task. This is not supported by UpdateTask.
+func (c *TodoistClient) MoveTask(id string, params MoveTaskParams) (bool, error) {
+	return c.MoveTaskContext(context.Background(), id, params)
+}
+
+// MoveTaskContext is like MoveTask but uses ctx for the request.
+func (c *TodoistClient) MoveTaskContext(ctx context.Context, id string, params MoveTaskParams) (bool, error) {
+	if err := params.Validate(); err != nil {
+		return false, err
+	}
+
+	args := struct {
+		ID string `json:"id"`
+		MoveTaskParams
+	}{id, params}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("item_move", args)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MoveProject moves a project below another project, or to the top level when parentID is empty.
+// This is not supported by UpdateProject.
+func (c *TodoistClient) MoveProject(id, parentID string) (bool, error) {
+	return c.MoveProjectContext(context.Background(), id, parentID)
+}
+
+// MoveProjectContext is like MoveProject but uses ctx for the request.
+func (c *TodoistClient) MoveProjectContext(ctx context.Context, id, parentID string) (bool, error) {
+	args := struct {
+		ID       string  `json:"id"`
+		ParentID *string `json:"parent_id"`
+	}{ID: id}
+	if parentID != "" {
+		args.ParentID = &parentID
+	}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("project_move", args)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// newUUID returns a random version 4 UUID, used to identify commands.
+func newUUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
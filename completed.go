@@ -0,0 +1,133 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaxCompletedTasksLimit is the largest page of completed tasks the API returns.
+const MaxCompletedTasksLimit = 200
+
+// CompletedTask is the record of a completed task as returned by the Sync API.
+type CompletedTask struct {
+	ID          string `json:"id"`
+	TaskID      string `json:"task_id"`
+	ProjectID   string `json:"project_id"`
+	SectionID   string `json:"section_id"`
+	Content     string `json:"content"`
+	CompletedAt string `json:"completed_at"`
+	NoteCount   int    `json:"note_count"`
+	// Item is the full task, set when the tasks were requested WithItems.
+	Item *SyncItem `json:"item_object,omitempty"`
+	// Notes are the task's comments, set when the tasks were requested WithNotes.
+	Notes []SyncNote `json:"notes,omitempty"`
+}
+
+// ToTask converts the record to the REST model, using the full task when it was requested.
+func (t CompletedTask) ToTask() Task {
+	if t.Item != nil {
+		task := t.Item.ToTask()
+		task.IsCompleted = true
+		return task
+	}
+	return Task{
+		ID:           t.TaskID,
+		ProjectID:    t.ProjectID,
+		SectionID:    t.SectionID,
+		Content:      t.Content,
+		IsCompleted:  true,
+		CommentCount: t.NoteCount,
+		URL:          "https://todoist.com/showTask?id=" + t.TaskID,
+	}
+}
+
+// CompletedTasksParams selects completed tasks. The zero value returns the most recently
+// completed tasks of all projects.
+type CompletedTasksParams struct {
+	ProjectID string
+	// Since and Until limit the completion time.
+	Since time.Time
+	Until time.Time
+	// Limit is the page size, up to MaxCompletedTasksLimit. Defaults to 30.
+	Limit  int
+	Offset int
+	// WithItems includes the full task of every record.
+	WithItems bool
+	// WithNotes includes the comments of every task.
+	WithNotes bool
+}
+
+// GetCompletedTasks returns a page of completed tasks, most recently completed first.
+func (c *TodoistClient) GetCompletedTasks(params CompletedTasksParams) ([]CompletedTask, error) {
+	return c.GetCompletedTasksContext(context.Background(), params)
+}
+
+// GetCompletedTasksContext is like GetCompletedTasks but uses ctx for the request.
+func (c *TodoistClient) GetCompletedTasksContext(ctx context.Context, params CompletedTasksParams) ([]CompletedTask, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	if params.ProjectID != "" {
+		form.Set("project_id", params.ProjectID)
+	}
+	if !params.Since.IsZero() {
+		form.Set("since", params.Since.UTC().Format("2006-01-02T15:04"))
+	}
+	if !params.Until.IsZero() {
+		form.Set("until", params.Until.UTC().Format("2006-01-02T15:04"))
+	}
+	if params.Limit > 0 {
+		form.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset > 0 {
+		form.Set("offset", strconv.Itoa(params.Offset))
+	}
+	if params.WithItems {
+		form.Set("annotate_items", "true")
+	}
+	if params.WithNotes {
+		form.Set("annotate_notes", "true")
+	}
+
+	resp, err := sendForm(ctx, c.HTTPClient, fmt.Sprintf("%s/completed/get_all", c.SyncBaseURL), c.tokenSource(), form)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []CompletedTask `json:"items"`
+	}
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// GetAllCompletedTasks pages through all completed tasks matching params, ignoring its Limit and Offset.
+func (c *TodoistClient) GetAllCompletedTasks(params CompletedTasksParams) ([]CompletedTask, error) {
+	return c.GetAllCompletedTasksContext(context.Background(), params)
+}
+
+// GetAllCompletedTasksContext is like GetAllCompletedTasks but uses ctx for the requests.
+func (c *TodoistClient) GetAllCompletedTasksContext(ctx context.Context, params CompletedTasksParams) ([]CompletedTask, error) {
+	params.Limit = MaxCompletedTasksLimit
+	params.Offset = 0
+
+	var all []CompletedTask
+	for {
+		page, err := c.GetCompletedTasksContext(ctx, params)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+		if len(page) < params.Limit {
+			return all, nil
+		}
+		params.Offset += len(page)
+	}
+}
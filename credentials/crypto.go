@@ -0,0 +1,116 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	kdfPassphrase = "pbkdf2-sha256"
+	kdfKeyFile    = "keyfile-hmac-sha256"
+
+	// pbkdf2Iterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256.
+	pbkdf2Iterations = 600000
+	keyLength        = 32
+	saltLength       = 16
+	minKeyFileLength = 32
+)
+
+// ErrDecrypt is returned when a credential file cannot be decrypted, usually because the key is wrong.
+var ErrDecrypt = errors.New("failed to decrypt credentials, wrong passphrase or key file?")
+
+// Key is the secret a credential file is encrypted with.
+type Key struct {
+	kdf    string
+	secret []byte
+}
+
+// Passphrase returns a key derived from a passphrase.
+func Passphrase(passphrase string) Key {
+	return Key{kdf: kdfPassphrase, secret: []byte(passphrase)}
+}
+
+// KeyFile returns a key read from a file of at least 32 random bytes.
+func KeyFile(path string) (Key, error) {
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, err
+	}
+	if len(secret) < minKeyFileLength {
+		return Key{}, fmt.Errorf("key file %s is too short, it needs at least %d bytes", path, minKeyFileLength)
+	}
+	return Key{kdf: kdfKeyFile, secret: secret}, nil
+}
+
+// GenerateKeyFile writes a new random key file readable only by the current user.
+func GenerateKeyFile(path string) error {
+	secret := make([]byte, keyLength)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	return os.WriteFile(path, secret, 0o600)
+}
+
+// derive computes the encryption key for a salt.
+func (k Key) derive(salt []byte, iterations int) ([]byte, error) {
+	switch k.kdf {
+	case kdfPassphrase:
+		if len(k.secret) == 0 {
+			return nil, errors.New("passphrase is empty")
+		}
+		return pbkdf2.Key(k.secret, salt, iterations, keyLength, sha256.New), nil
+	case kdfKeyFile:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(salt)
+		return mac.Sum(nil), nil
+	}
+	return nil, errors.New("no key given")
+}
+
+// seal encrypts plaintext with AES-256-GCM, authenticating additionalData along with it, and returns
+// the nonce and ciphertext.
+func seal(key, plaintext, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext produced by seal with the same additional data.
+func open(key, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
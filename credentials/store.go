@@ -0,0 +1,302 @@
+// Package credentials stores Todoist tokens for named profiles in an encrypted file.
+package credentials
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// fileVersion is the version of the credential file format. The header is authenticated along with the data.
+const fileVersion = 2
+
+// ErrProfileNotFound is returned when a profile does not exist in the store.
+var ErrProfileNotFound = errors.New("credential profile not found")
+
+// ErrTokenExpired is returned by a profile's token source when its OAuth token has expired.
+var ErrTokenExpired = errors.New("access token has expired")
+
+// Kind distinguishes personal API tokens from tokens obtained through OAuth.
+type Kind string
+
+const (
+	KindPersonal Kind = "personal"
+	KindOAuth    Kind = "oauth"
+)
+
+// Credential is a stored Todoist token.
+type Credential struct {
+	Kind         Kind            `json:"kind"`
+	AccessToken  string          `json:"access_token"`
+	TokenType    string          `json:"token_type,omitempty"`
+	RefreshToken string          `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time       `json:"expires_at,omitempty"`
+	Scopes       []todoist.Scope `json:"scopes,omitempty"`
+	ClientID     string          `json:"client_id,omitempty"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// Expired reports whether the credential has an expiry time that has passed.
+func (c Credential) Expired() bool {
+	return !c.ExpiresAt.IsZero() && time.Now().After(c.ExpiresAt)
+}
+
+// envelope is the on-disk format of a credential file.
+type envelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations,omitempty"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// additionalData returns the header fields that are authenticated together with the encrypted data,
+// so that changing the key derivation parameters makes decryption fail.
+func (e envelope) additionalData() []byte {
+	return []byte(fmt.Sprintf("todoist-credentials\x00%d\x00%s\x00%d\x00%x", e.Version, e.KDF, e.Iterations, e.Salt))
+}
+
+// contents is the decrypted content of a credential file.
+type contents struct {
+	Default  string                `json:"default,omitempty"`
+	Profiles map[string]Credential `json:"profiles"`
+}
+
+// Store is an encrypted credential file holding tokens for named profiles such as "work" or "personal".
+type Store struct {
+	path string
+	key  Key
+
+	mu         sync.Mutex
+	salt       []byte
+	iterations int
+	derived    []byte
+	contents   contents
+}
+
+// Open decrypts the credential file at path, or prepares a new one if it does not exist yet.
+func Open(path string, key Key) (*Store, error) {
+	s := &Store{
+		path:       path,
+		key:        key,
+		iterations: pbkdf2Iterations,
+		contents:   contents{Profiles: make(map[string]Credential)},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.salt = make([]byte, saltLength)
+		if _, err := rand.Read(s.salt); err != nil {
+			return nil, err
+		}
+		if s.derived, err = key.derive(s.salt, s.iterations); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("invalid credential file %s: %w", path, err)
+	}
+	if env.Version != fileVersion {
+		return nil, fmt.Errorf("unsupported credential file version %d", env.Version)
+	}
+	if env.KDF != key.kdf {
+		return nil, fmt.Errorf("credential file %s is encrypted with %s, got a different kind of key", path, env.KDF)
+	}
+
+	// The header is only authenticated after the key is derived, so a weakened count is rejected up front.
+	if env.KDF == kdfPassphrase && env.Iterations < pbkdf2Iterations {
+		return nil, fmt.Errorf("iteration count %d in credential file %s is below the minimum of %d", env.Iterations, path, pbkdf2Iterations)
+	}
+
+	s.salt = env.Salt
+	s.iterations = env.Iterations
+	if s.derived, err = key.derive(env.Salt, env.Iterations); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := open(s.derived, env.Nonce, env.Data, env.additionalData())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plaintext, &s.contents); err != nil {
+		return nil, fmt.Errorf("invalid credential data: %w", err)
+	}
+	if s.contents.Profiles == nil {
+		s.contents.Profiles = make(map[string]Credential)
+	}
+
+	return s, nil
+}
+
+// Profiles returns the names of all stored profiles, sorted.
+func (s *Store) Profiles() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.contents.Profiles))
+	for name := range s.contents.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the name of the default profile.
+func (s *Store) Default() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.contents.Default
+}
+
+// SetDefault makes an existing profile the default and saves the store.
+func (s *Store) SetDefault(profile string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.contents.Profiles[profile]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, profile)
+	}
+	s.contents.Default = profile
+	return s.saveLocked()
+}
+
+// Get returns the credential of a profile; an empty name selects the default profile.
+func (s *Store) Get(profile string) (Credential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.getLocked(profile)
+}
+
+// Set stores the credential of a profile and saves the store. The first profile becomes the default.
+func (s *Store) Set(profile string, cred Credential) error {
+	if profile == "" {
+		return errors.New("profile name is empty")
+	}
+	if cred.AccessToken == "" {
+		return errors.New("access token is empty")
+	}
+	if cred.Kind == "" {
+		cred.Kind = KindPersonal
+	}
+	cred.UpdatedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.contents.Profiles[profile] = cred
+	if s.contents.Default == "" {
+		s.contents.Default = profile
+	}
+	return s.saveLocked()
+}
+
+// Delete removes a profile and saves the store.
+func (s *Store) Delete(profile string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.contents.Profiles[profile]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, profile)
+	}
+	delete(s.contents.Profiles, profile)
+	if s.contents.Default == profile {
+		s.contents.Default = ""
+	}
+	return s.saveLocked()
+}
+
+// TokenSource returns a token source reading the current token of a profile on every request,
+// so tokens updated with Set are picked up by existing clients.
+func (s *Store) TokenSource(profile string) todoist.TokenSource {
+	return todoist.TokenSourceFunc(func() (string, error) {
+		cred, err := s.Get(profile)
+		if err != nil {
+			return "", err
+		}
+		if cred.Expired() {
+			return "", fmt.Errorf("%w: profile %s", ErrTokenExpired, profile)
+		}
+		return cred.AccessToken, nil
+	})
+}
+
+// Client returns a Todoist client authenticated with a profile; an empty name selects the default profile.
+func (s *Store) Client(profile string) (*todoist.TodoistClient, error) {
+	if _, err := s.Get(profile); err != nil {
+		return nil, err
+	}
+	return todoist.NewTodoistClientWithTokenSource(s.TokenSource(profile)), nil
+}
+
+func (s *Store) getLocked(profile string) (Credential, error) {
+	if profile == "" {
+		profile = s.contents.Default
+	}
+	cred, ok := s.contents.Profiles[profile]
+	if !ok {
+		return Credential{}, fmt.Errorf("%w: %q", ErrProfileNotFound, profile)
+	}
+	return cred, nil
+}
+
+// saveLocked encrypts the store and atomically replaces the credential file. The caller must hold s.mu.
+func (s *Store) saveLocked() error {
+	plaintext, err := json.Marshal(s.contents)
+	if err != nil {
+		return err
+	}
+
+	env := envelope{
+		Version: fileVersion,
+		KDF:     s.key.kdf,
+		Salt:    s.salt,
+	}
+	if s.key.kdf == kdfPassphrase {
+		env.Iterations = s.iterations
+	}
+	if env.Nonce, env.Data, err = seal(s.derived, plaintext, env.additionalData()); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
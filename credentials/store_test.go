@@ -0,0 +1,194 @@
+package credentials_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go/credentials"
+)
+
+func newKeyFile(t *testing.T) credentials.Key {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "key")
+	if err := credentials.GenerateKeyFile(path); err != nil {
+		t.Fatal(err)
+	}
+	key, err := credentials.KeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newStoreFile writes a credential file with one profile and returns its path.
+func newStoreFile(t *testing.T, key credentials.Key) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	store, err := credentials.Open(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("work", credentials.Credential{AccessToken: "secret-token"}); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// tamper changes a header field of a credential file.
+func tamper(t *testing.T, path, field string, value interface{}) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env map[string]interface{}
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	env[field] = value
+	if data, err = json.Marshal(env); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	key := newKeyFile(t)
+	path := newStoreFile(t, key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatal("credential file contains the token in plain text")
+	}
+
+	store, err := credentials.Open(path, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := store.Set("personal", credentials.Credential{AccessToken: "other-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = credentials.Open(path, key)
+	if err != nil {
+		t.Fatalf("Open after Set: %v", err)
+	}
+	if got := strings.Join(store.Profiles(), ","); got != "personal,work" {
+		t.Errorf("Profiles = %s, want personal,work", got)
+	}
+	// The first profile stays the default.
+	if cred, err := store.Get(""); err != nil || cred.AccessToken != "secret-token" || cred.Kind != credentials.KindPersonal {
+		t.Errorf("Get default = %+v, %v", cred, err)
+	}
+	if _, err := store.Get("missing"); !errors.Is(err, credentials.ErrProfileNotFound) {
+		t.Errorf("Get missing = %v, want ErrProfileNotFound", err)
+	}
+}
+
+func TestStoreExpiredToken(t *testing.T) {
+	store, err := credentials.Open(filepath.Join(t.TempDir(), "credentials.json"), newKeyFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := credentials.Credential{Kind: credentials.KindOAuth, AccessToken: "old", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := store.Set("oauth", expired); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.TokenSource("oauth").Token(); !errors.Is(err, credentials.ErrTokenExpired) {
+		t.Errorf("Token = %v, want ErrTokenExpired", err)
+	}
+}
+
+func TestStoreRejectsTampering(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+	}{
+		{"salt", "salt", "AAAAAAAAAAAAAAAAAAAAAA=="},
+		{"nonce", "nonce", "AAAAAAAAAAAAAAAA"},
+		{"data", "data", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+		{"old version", "version", 1},
+		{"future version", "version", 3},
+		{"kdf", "kdf", "pbkdf2-sha256"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := newKeyFile(t)
+			path := newStoreFile(t, key)
+			tamper(t, path, tt.field, tt.value)
+
+			if store, err := credentials.Open(path, key); err == nil {
+				t.Errorf("Open of a file with a changed %s succeeded with profiles %v", tt.field, store.Profiles())
+			}
+		})
+	}
+}
+
+func TestStoreWrongKey(t *testing.T) {
+	path := newStoreFile(t, newKeyFile(t))
+
+	if _, err := credentials.Open(path, newKeyFile(t)); !errors.Is(err, credentials.ErrDecrypt) {
+		t.Errorf("Open with another key = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestStorePassphraseIterations(t *testing.T) {
+	key := credentials.Passphrase("correct horse battery staple")
+	path := newStoreFile(t, key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env struct{ Iterations int }
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		iterations int
+		check      func(error) bool
+	}{
+		// A weakened count is rejected before the slow key derivation.
+		{"lowered", 1000, func(err error) bool { return err != nil && strings.Contains(err.Error(), "below the minimum") }},
+		// A raised count passes the minimum, but the header is authenticated.
+		{"raised", env.Iterations + 1, func(err error) bool { return errors.Is(err, credentials.ErrDecrypt) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			tamper(t, path, "iterations", tt.iterations)
+
+			if _, err := credentials.Open(path, key); !tt.check(err) {
+				t.Errorf("Open with %d iterations = %v", tt.iterations, err)
+			}
+		})
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := credentials.Open(path, credentials.Passphrase("wrong")); !errors.Is(err, credentials.ErrDecrypt) {
+		t.Errorf("Open with a wrong passphrase = %v, want ErrDecrypt", err)
+	}
+}
@@ -0,0 +1,187 @@
+// Package csvtemplate reads and writes projects in the CSV format of Todoist's project templates,
+// the format used by "Export as a template" and "Import from template" in the Todoist apps.
+package csvtemplate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// RowType is the TYPE column of a row.
+type RowType string
+
+const (
+	TypeTask    RowType = "task"
+	TypeSection RowType = "section"
+	TypeNote    RowType = "note"
+	TypeMeta    RowType = "meta"
+)
+
+// Header lists the columns written by Write, in the order Todoist uses.
+var Header = []string{
+	"TYPE", "CONTENT", "DESCRIPTION", "PRIORITY", "INDENT", "AUTHOR", "RESPONSIBLE",
+	"DATE", "DATE_LANG", "TIMEZONE", "DURATION", "DURATION_UNIT",
+}
+
+// Row is a row of a template.
+//
+// For tasks, Content holds the task content followed by its labels as "@label" words, Priority
+// is the number shown in the apps (1 is P1) and Indent is 1 for top-level tasks, 2 for their
+// subtasks and so on. Date is a due date in any form Todoist understands, written in DateLang.
+// Sections only use Content. Notes are comments on the task before them, or on the project when
+// no task comes before them in the file or the current section.
+type Row struct {
+	Type         RowType
+	Content      string
+	Description  string
+	Priority     int
+	Indent       int
+	Author       string
+	Responsible  string
+	Date         string
+	DateLang     string
+	Timezone     string
+	Duration     int
+	DurationUnit todoist.DurationUnit
+	// Line is the line number the row was read from, zero for rows not read by Read.
+	Line int
+}
+
+// Read reads the rows of a template. Columns are matched by the header, so missing and
+// additional columns are allowed; blank rows are skipped.
+func Read(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csvtemplate: empty file")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csvtemplate: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["TYPE"]; !ok {
+		return nil, errors.New("csvtemplate: missing TYPE column")
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return nil, errors.New("csvtemplate: missing CONTENT column")
+	}
+
+	var rows []Row
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csvtemplate: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if field("TYPE") == "" && field("CONTENT") == "" {
+			continue
+		}
+
+		row := Row{
+			Type:         RowType(strings.ToLower(field("TYPE"))),
+			Content:      field("CONTENT"),
+			Description:  field("DESCRIPTION"),
+			Author:       field("AUTHOR"),
+			Responsible:  field("RESPONSIBLE"),
+			Date:         field("DATE"),
+			DateLang:     field("DATE_LANG"),
+			Timezone:     field("TIMEZONE"),
+			DurationUnit: todoist.DurationUnit(strings.ToLower(field("DURATION_UNIT"))),
+			Line:         line,
+		}
+		if row.DurationUnit == "none" {
+			row.DurationUnit = ""
+		}
+		for name, dst := range map[string]*int{"PRIORITY": &row.Priority, "INDENT": &row.Indent, "DURATION": &row.Duration} {
+			v := field(name)
+			if v == "" || strings.EqualFold(v, "none") {
+				continue
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("csvtemplate: line %d: invalid %s %q", line, name, v)
+			}
+			*dst = n
+		}
+		rows = append(rows, row)
+	}
+}
+
+// Write writes the rows as a template with the columns of Header.
+func Write(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	cw.Write(Header)
+	for _, row := range rows {
+		cw.Write([]string{
+			string(row.Type),
+			row.Content,
+			row.Description,
+			optionalInt(row.Priority),
+			optionalInt(row.Indent),
+			row.Author,
+			row.Responsible,
+			row.Date,
+			row.DateLang,
+			row.Timezone,
+			optionalInt(row.Duration),
+			string(row.DurationUnit),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func optionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// splitLabels separates the "@label" words at the end of a task's content from the content.
+func splitLabels(content string) (string, []string) {
+	rest := strings.TrimSpace(content)
+	var labels []string
+	for {
+		i := strings.LastIndexAny(rest, " \t") + 1
+		word := rest[i:]
+		if i == 0 || len(word) < 2 || word[0] != '@' {
+			break
+		}
+		labels = append([]string{word[1:]}, labels...)
+		rest = strings.TrimSpace(rest[:i])
+	}
+	if labels == nil {
+		return content, nil
+	}
+	return rest, labels
+}
+
+// joinLabels appends labels to a task's content as "@label" words.
+func joinLabels(content string, labels []string) string {
+	for _, label := range labels {
+		content += " @" + label
+	}
+	return content
+}
@@ -0,0 +1,148 @@
+package csvtemplate
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Project is the content of a project as stored in a template.
+type Project struct {
+	Sections []todoist.Section
+	Tasks    []todoist.Task
+	// Comments are the comments on the project itself.
+	Comments []todoist.Comment
+	// TaskComments holds the comments of each task by task ID.
+	TaskComments map[string][]todoist.Comment
+}
+
+// Fetch reads the sections, active tasks and comments of a project.
+func Fetch(client *todoist.TodoistClient, projectID string) (*Project, error) {
+	p := &Project{TaskComments: make(map[string][]todoist.Comment)}
+
+	var err error
+	if p.Sections, err = client.GetSections(projectID); err != nil {
+		return nil, fmt.Errorf("csvtemplate: list sections: %w", err)
+	}
+	if p.Tasks, err = client.GetTasks(projectID, "", ""); err != nil {
+		return nil, fmt.Errorf("csvtemplate: list tasks: %w", err)
+	}
+	if p.Comments, err = client.GetComments("", projectID); err != nil {
+		return nil, fmt.Errorf("csvtemplate: list project comments: %w", err)
+	}
+	for _, t := range p.Tasks {
+		if t.CommentCount == 0 {
+			continue
+		}
+		comments, err := client.GetComments(t.ID, "")
+		if err != nil {
+			return nil, fmt.Errorf("csvtemplate: list comments of task %s: %w", t.ID, err)
+		}
+		p.TaskComments[t.ID] = comments
+	}
+	return p, nil
+}
+
+// Export writes a project's sections, tasks, subtasks and comments as a template.
+func Export(client *todoist.TodoistClient, projectID string, w io.Writer) error {
+	p, err := Fetch(client, projectID)
+	if err != nil {
+		return err
+	}
+	return Write(w, p.Rows())
+}
+
+// Rows converts the project to template rows: project comments first, then the tasks outside
+// sections, then every section followed by its tasks. Subtasks follow their parent with a
+// deeper indent and comments follow their task.
+func (p *Project) Rows() []Row {
+	var rows []Row
+	for _, c := range p.Comments {
+		rows = append(rows, Row{Type: TypeNote, Content: c.Content})
+	}
+
+	children := make(map[string][]todoist.Task)
+	ids := make(map[string]bool, len(p.Tasks))
+	for _, t := range p.Tasks {
+		ids[t.ID] = true
+	}
+	for _, t := range p.Tasks {
+		parent := "section:" + t.SectionID
+		if t.ParentID != "" && ids[t.ParentID] {
+			parent = t.ParentID
+		}
+		children[parent] = append(children[parent], t)
+	}
+	for _, tasks := range children {
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Order < tasks[j].Order })
+	}
+
+	var walk func(parent string, indent int)
+	walk = func(parent string, indent int) {
+		for _, t := range children[parent] {
+			rows = append(rows, taskRow(t, indent))
+			for _, c := range p.TaskComments[t.ID] {
+				rows = append(rows, Row{Type: TypeNote, Content: c.Content})
+			}
+			walk(t.ID, indent+1)
+		}
+	}
+
+	walk("section:", 1)
+	sections := append([]todoist.Section(nil), p.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Order < sections[j].Order })
+	for _, s := range sections {
+		rows = append(rows, Row{Type: TypeSection, Content: s.Name})
+		walk("section:"+s.ID, 1)
+	}
+	return rows
+}
+
+func taskRow(t todoist.Task, indent int) Row {
+	row := Row{
+		Type:        TypeTask,
+		Content:     joinLabels(t.Content, t.Labels),
+		Description: t.Description,
+		Priority:    t.Priority.UI(),
+		Indent:      indent,
+	}
+	if t.AssigneeID != "" {
+		row.Responsible = "(" + t.AssigneeID + ")"
+	}
+	if t.Due != nil {
+		row.Date, row.Timezone = formatDue(t.Due)
+		row.DateLang = "en"
+	}
+	if t.Duration != nil && t.Duration.Amount > 0 {
+		row.Duration, row.DurationUnit = t.Duration.Amount, t.Duration.Unit
+	}
+	return row
+}
+
+// formatDue returns the DATE and TIMEZONE columns of a due. Recurring dues keep their
+// recurrence, other dues are written as absolute dates so they mean the same after an import.
+func formatDue(due *todoist.TaskDue) (date, timezone string) {
+	if due.IsRecurring {
+		return due.String, due.Timezone
+	}
+	if due.Datetime == "" {
+		return due.Date, ""
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, due.Datetime); err == nil {
+		if loc, err := time.LoadLocation(due.Timezone); due.Timezone != "" && err == nil {
+			return t.In(loc).Format(dateTimeLayout), due.Timezone
+		}
+		return t.UTC().Format(dateTimeLayout), "UTC"
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05", due.Datetime); err == nil {
+		return t.Format(dateTimeLayout), ""
+	}
+	return due.String, due.Timezone
+}
+
+// dateTimeLayout is how timed dues are written to the DATE column.
+const dateTimeLayout = "2006-01-02 15:04"
@@ -0,0 +1,351 @@
+package csvtemplate
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// ImportOptions configures an import.
+type ImportOptions struct {
+	// ProjectID is the existing project to import into. Sections of the template that already exist
+	// in it, by name, are reused.
+	ProjectID string
+	// ProjectName is the name of the project created when ProjectID is empty.
+	ProjectName string
+	// DryRun checks the template and reports what would be created without changing anything.
+	DryRun bool
+	// Assignees assigns tasks to the user ID in the RESPONSIBLE column, which only works in shared
+	// projects. By default the column is ignored.
+	Assignees bool
+}
+
+// Change is a step of an import.
+type Change struct {
+	// Line is the line of the template the change comes from, zero for the project.
+	Line int
+	// Action is "create" or "reuse".
+	Action string
+	Type   string // "project", "section", "task" or "comment"
+	Name   string
+	// ID is the ID of the created or reused object. It is empty for objects a dry run would create.
+	ID string
+}
+
+// Report describes what an import did, or would do in a dry run.
+type Report struct {
+	DryRun    bool
+	ProjectID string
+	Changes   []Change
+}
+
+// Count returns the number of objects of a type created, or to be created in a dry run.
+func (r *Report) Count(typ string) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Type == typ && c.Action == "create" {
+			n++
+		}
+	}
+	return n
+}
+
+// String lists the changes, one per line, followed by a summary.
+func (r *Report) String() string {
+	var b strings.Builder
+	verb := func(action string) string {
+		if r.DryRun {
+			return "would " + action
+		}
+		return pastTense[action]
+	}
+	for _, c := range r.Changes {
+		if c.Line > 0 {
+			fmt.Fprintf(&b, "line %d: ", c.Line)
+		}
+		fmt.Fprintf(&b, "%s %s %q", verb(c.Action), c.Type, c.Name)
+		if c.ID != "" {
+			fmt.Fprintf(&b, " (%s)", c.ID)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d sections, %d tasks, %d comments", r.Count("section"), r.Count("task"), r.Count("comment"))
+	if r.DryRun {
+		b.WriteString(" (dry run, nothing was changed)")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+var pastTense = map[string]string{"create": "created", "reuse": "reused"}
+
+// step is a planned change with the steps it depends on, as indexes into the plan.
+type step struct {
+	change  Change
+	section int // Section step a task is created in, or -1
+	parent  int // Parent task step of a subtask, or -1
+	task    int // Task step a comment is added to, or -1 for a project comment
+	params  todoist.TaskParams
+}
+
+// Import reads a template and creates its sections, tasks and comments in a project.
+//
+// The whole template is checked before anything is created; problems are returned as a
+// todoist.ValidationErrors with one entry per line. Requests are then sent one after another, and
+// the Report returned with an error holds the changes already made, with their lines.
+func Import(client *todoist.TodoistClient, r io.Reader, opts ImportOptions) (*Report, error) {
+	rows, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ProjectID == "" && strings.TrimSpace(opts.ProjectName) == "" {
+		return nil, todoist.ValidationErrors{{Field: "project", Message: "a project ID or name is required"}}
+	}
+
+	existing := make(map[string]string)
+	if opts.ProjectID != "" {
+		sections, err := client.GetSections(opts.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("csvtemplate: list sections: %w", err)
+		}
+		for _, s := range sections {
+			existing[s.Name] = s.ID
+		}
+	}
+
+	plan, err := planImport(rows, existing, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: opts.DryRun, ProjectID: opts.ProjectID}
+	if opts.ProjectID == "" {
+		report.Changes = append(report.Changes, Change{Action: "create", Type: "project", Name: opts.ProjectName})
+	}
+	if opts.DryRun {
+		for _, s := range plan {
+			report.Changes = append(report.Changes, s.change)
+		}
+		return report, nil
+	}
+
+	if opts.ProjectID == "" {
+		project, err := client.CreateProject(todoist.ProjectParams{Name: opts.ProjectName})
+		if err != nil {
+			return nil, fmt.Errorf("csvtemplate: create project: %w", err)
+		}
+		report.ProjectID = project.ID
+		report.Changes[0].ID = project.ID
+	}
+
+	for i := range plan {
+		if err := apply(client, plan, i, report.ProjectID); err != nil {
+			return report, fmt.Errorf("csvtemplate: line %d: %w", plan[i].change.Line, err)
+		}
+		report.Changes = append(report.Changes, plan[i].change)
+	}
+	return report, nil
+}
+
+// apply makes the change of a step, recording the created ID in the plan.
+func apply(client *todoist.TodoistClient, plan []step, i int, projectID string) error {
+	s := &plan[i]
+	switch s.change.Type {
+	case "section":
+		if s.change.Action == "reuse" {
+			return nil
+		}
+		section, err := client.CreateSection(todoist.SectionParams{ProjectID: projectID, Name: s.change.Name})
+		if err != nil {
+			return err
+		}
+		s.change.ID = section.ID
+
+	case "task":
+		params := s.params
+		params.ProjectID = projectID
+		if s.section >= 0 {
+			params.SectionID = plan[s.section].change.ID
+		}
+		if s.parent >= 0 {
+			params.ParentID = plan[s.parent].change.ID
+		}
+		task, err := client.CreateTask(params)
+		if err != nil {
+			return err
+		}
+		s.change.ID = task.ID
+
+	case "comment":
+		params := todoist.CommentParams{Content: s.change.Name}
+		if s.task >= 0 {
+			params.TaskID = plan[s.task].change.ID
+		} else {
+			params.ProjectID = projectID
+		}
+		comment, err := client.CreateComment(params)
+		if err != nil {
+			return err
+		}
+		s.change.ID = comment.ID
+	}
+	return nil
+}
+
+// planImport checks the rows and turns them into steps.
+func planImport(rows []Row, existing map[string]string, opts ImportOptions) ([]step, error) {
+	var errs todoist.ValidationErrors
+	fail := func(row Row, format string, args ...interface{}) {
+		errs = append(errs, todoist.ValidationError{Field: fmt.Sprintf("line %d", row.Line), Message: fmt.Sprintf(format, args...)})
+	}
+
+	var plan []step
+	section := -1
+	var parents []int // Task steps by indent - 1 along the current branch
+	for _, row := range rows {
+		switch row.Type {
+		case TypeMeta:
+
+		case TypeSection:
+			if row.Content == "" {
+				fail(row, "section name is required")
+				continue
+			}
+			change := Change{Line: row.Line, Action: "create", Type: "section", Name: row.Content}
+			if id, ok := existing[row.Content]; ok {
+				change.Action, change.ID = "reuse", id
+			}
+			section, parents = len(plan), nil
+			plan = append(plan, step{change: change, section: -1, parent: -1, task: -1})
+
+		case TypeTask:
+			indent := max(row.Indent, 1)
+			if indent > len(parents)+1 {
+				fail(row, "indent %d has no parent task", indent)
+				continue
+			}
+			params, err := taskParams(row, opts)
+			if err != nil {
+				fail(row, "%v", err)
+				continue
+			}
+			if err := params.Validate(); err != nil {
+				fail(row, "%v", err)
+				continue
+			}
+
+			parents = parents[:indent-1]
+			parent := -1
+			if indent > 1 {
+				parent = parents[indent-2]
+			}
+			parents = append(parents, len(plan))
+			plan = append(plan, step{
+				change:  Change{Line: row.Line, Action: "create", Type: "task", Name: params.Content},
+				section: section,
+				parent:  parent,
+				task:    -1,
+				params:  params,
+			})
+
+		case TypeNote:
+			if row.Content == "" {
+				fail(row, "comment content is required")
+				continue
+			}
+			task := -1
+			if len(parents) > 0 {
+				task = parents[len(parents)-1]
+			}
+			plan = append(plan, step{
+				change:  Change{Line: row.Line, Action: "create", Type: "comment", Name: row.Content},
+				section: -1,
+				parent:  -1,
+				task:    task,
+			})
+
+		default:
+			fail(row, "unknown type %q", row.Type)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return plan, nil
+}
+
+var responsibleID = regexp.MustCompile(`\(([0-9]+)\)\s*$`)
+
+// taskParams maps a task row to the parameters of a new task, without its location.
+func taskParams(row Row, opts ImportOptions) (todoist.TaskParams, error) {
+	content, labels := splitLabels(row.Content)
+	params := todoist.TaskParams{
+		Content:     content,
+		Description: row.Description,
+		Labels:      labels,
+	}
+	if strings.TrimSpace(content) == "" {
+		return params, fmt.Errorf("task content is required")
+	}
+
+	if row.Priority != 0 {
+		p, err := todoist.PriorityFromUI(row.Priority)
+		if err != nil {
+			return params, err
+		}
+		params.Priority = p
+	}
+
+	if opts.Assignees && row.Responsible != "" {
+		m := responsibleID.FindStringSubmatch(row.Responsible)
+		if m == nil {
+			return params, fmt.Errorf("no user ID in responsible %q", row.Responsible)
+		}
+		params.AssigneeID = m[1]
+	}
+
+	if row.Date != "" {
+		if err := setDue(&params, row); err != nil {
+			return params, err
+		}
+	}
+
+	if row.Duration > 0 {
+		params.Duration = row.Duration
+		params.DurationUnit = row.DurationUnit
+		if params.DurationUnit == "" {
+			params.DurationUnit = todoist.DurationUnitMinute
+		}
+	}
+	return params, nil
+}
+
+// setDue sets the due date of a task from the DATE column. Absolute dates and times are sent as
+// such, anything else is left to Todoist's date parser.
+func setDue(params *todoist.TaskParams, row Row) error {
+	if _, err := time.Parse(time.DateOnly, row.Date); err == nil {
+		params.DueDate = row.Date
+		return nil
+	}
+
+	if row.Timezone != "" {
+		if t, err := time.Parse(dateTimeLayout, row.Date); err == nil {
+			loc, err := time.LoadLocation(row.Timezone)
+			if err != nil {
+				return fmt.Errorf("unknown timezone %q", row.Timezone)
+			}
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+			params.DueDatetime = t.UTC().Format(time.RFC3339)
+			return nil
+		}
+	}
+
+	params.DueString = row.Date
+	params.DueLang = row.DateLang
+	return nil
+}
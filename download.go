@@ -0,0 +1,268 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// ErrAttachmentMismatch is returned when a downloaded file does not match its attachment metadata.
+var ErrAttachmentMismatch = errors.New("downloaded file does not match attachment")
+
+// mirrorManifestName is the file in a mirror directory that records what has been downloaded.
+const mirrorManifestName = ".todoist-attachments.json"
+
+// DownloadAttachment streams the file of an attachment into w and returns the number of bytes written.
+// The size and content type are checked against the attachment metadata where it is known.
+// The API token is only sent to Todoist hosts; files linked from other hosts are fetched without it.
+func (c *TodoistClient) DownloadAttachment(ctx context.Context, attachment *Attachment, w io.Writer) (int64, error) {
+	if attachment == nil || attachment.FileURL == "" {
+		return 0, errors.New("attachment has no file URL")
+	}
+
+	resp, err := c.fetchFile(ctx, attachment.FileURL)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, newAPIError(resp, "download attachment")
+	}
+	defer resp.Body.Close()
+
+	if attachment.FileType != "" && !sameMediaType(attachment.FileType, resp.Header.Get("Content-Type")) {
+		return 0, fmt.Errorf("%w: content type %q, expected %q", ErrAttachmentMismatch, resp.Header.Get("Content-Type"), attachment.FileType)
+	}
+	if attachment.FileSize > 0 && resp.ContentLength >= 0 && resp.ContentLength != attachment.FileSize {
+		return 0, fmt.Errorf("%w: content length %d, expected %d", ErrAttachmentMismatch, resp.ContentLength, attachment.FileSize)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, err
+	}
+	if attachment.FileSize > 0 && n != attachment.FileSize {
+		return n, fmt.Errorf("%w: got %d bytes, expected %d", ErrAttachmentMismatch, n, attachment.FileSize)
+	}
+
+	return n, nil
+}
+
+// fetchFile requests a file, authenticated only if it is hosted by Todoist or the configured API hosts.
+// Attachment URLs are set by collaborators, so any other host must not see the token,
+// including hosts that a trusted one redirects to.
+func (c *TodoistClient) fetchFile(ctx context.Context, fileURL string) (*http.Response, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file URL: %w", err)
+	}
+	if c.trustedHost(u) {
+		client := *c.HTTPClient
+		checkRedirect := client.CheckRedirect
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			// net/http only drops the header for other domains, not for other ports or schemes.
+			if !c.trustedHost(req.URL) {
+				req.Header.Del("Authorization")
+			}
+			if checkRedirect != nil {
+				return checkRedirect(req, via)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		}
+		return sendBody(ctx, &client, "GET", fileURL, c.tokenSource(), "application/json", nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.HTTPClient.Do(req)
+}
+
+// trustedHost reports whether the token may be sent to the host of u: todoist.com, its subdomains,
+// and the hosts of BaseURL and SyncBaseURL, always over HTTPS unless the configured host is used.
+func (c *TodoistClient) trustedHost(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, base := range []string{c.BaseURL, c.SyncBaseURL} {
+		if b, err := url.Parse(base); err == nil && b.Host != "" && strings.EqualFold(b.Host, u.Host) && b.Scheme == u.Scheme {
+			return true
+		}
+	}
+	return u.Scheme == "https" && (host == "todoist.com" || strings.HasSuffix(host, ".todoist.com"))
+}
+
+// MirrorResult lists the files handled by MirrorProjectAttachments, relative to the mirror directory.
+type MirrorResult struct {
+	Downloaded []string
+	Skipped    []string
+}
+
+// mirrorEntry records a downloaded attachment in the mirror manifest.
+type mirrorEntry struct {
+	CommentID string `json:"comment_id"`
+	FileURL   string `json:"file_url"`
+	FileSize  int64  `json:"file_size"`
+}
+
+// MirrorProjectAttachments downloads the attachments of all comments on a project and its active tasks into dir.
+// Files are named after their comment ID and skipped when they are unchanged since the last run.
+func (c *TodoistClient) MirrorProjectAttachments(ctx context.Context, projectID, dir string) (*MirrorResult, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	comments, err := c.GetCommentsContext(ctx, "", projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := c.GetTasksContext(ctx, projectID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.CommentCount == 0 {
+			continue
+		}
+		taskComments, err := c.GetCommentsContext(ctx, task.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, taskComments...)
+	}
+
+	manifest, err := readMirrorManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MirrorResult{}
+	for _, comment := range comments {
+		attachment := comment.Attachment
+		if attachment == nil || attachment.FileURL == "" || attachment.ResourceType == "url" {
+			continue
+		}
+
+		name := mirrorFileName(comment.ID, attachment.FileName)
+		path := filepath.Join(dir, name)
+
+		if entry, ok := manifest[name]; ok && entry.FileURL == attachment.FileURL {
+			if info, err := os.Stat(path); err == nil && info.Size() == entry.FileSize {
+				result.Skipped = append(result.Skipped, name)
+				continue
+			}
+		}
+
+		size, err := c.downloadToFile(ctx, attachment, path)
+		if err != nil {
+			return result, fmt.Errorf("failed to mirror attachment of comment %s: %w", comment.ID, err)
+		}
+
+		manifest[name] = mirrorEntry{CommentID: comment.ID, FileURL: attachment.FileURL, FileSize: size}
+		result.Downloaded = append(result.Downloaded, name)
+
+		if err := writeMirrorManifest(dir, manifest); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// downloadToFile downloads an attachment into a temporary file and moves it into place once complete.
+func (c *TodoistClient) downloadToFile(ctx context.Context, attachment *Attachment, path string) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := c.DownloadAttachment(ctx, attachment, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return n, os.Rename(tmp.Name(), path)
+}
+
+// readMirrorManifest loads the mirror manifest of dir, returning an empty one if none exists yet.
+func readMirrorManifest(dir string) (map[string]mirrorEntry, error) {
+	manifest := make(map[string]mirrorEntry)
+
+	data, err := os.ReadFile(filepath.Join(dir, mirrorManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid mirror manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// writeMirrorManifest atomically replaces the mirror manifest of dir.
+func writeMirrorManifest(dir string, manifest map[string]mirrorEntry) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, mirrorManifestName)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// mirrorFileName builds a stable, filesystem-safe file name for an attachment.
+func mirrorFileName(commentID, fileName string) string {
+	base := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, filepath.Base(fileName))
+
+	if base == "" || base == "." || base == ".." {
+		base = "attachment"
+	}
+	return commentID + "_" + base
+}
+
+// sameMediaType reports whether two Content-Type values describe the same media type.
+// Types that cannot be parsed are not checked.
+func sameMediaType(expected, actual string) bool {
+	if actual == "" {
+		return true
+	}
+
+	want, _, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return true
+	}
+	got, _, err := mime.ParseMediaType(actual)
+	if err != nil {
+		return false
+	}
+
+	// Todoist reports some types by their file extension, e.g. "image/jpg".
+	normalize := strings.NewReplacer("image/jpg", "image/jpeg")
+	return normalize.Replace(want) == normalize.Replace(got)
+}
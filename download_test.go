@@ -0,0 +1,126 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestTrustedHost(t *testing.T) {
+	c := NewTodoistClient("token")
+	c.BaseURL = "http://127.0.0.1:8080/rest/v2"
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://todoist.com/file", true},
+		{"https://files.todoist.com/file", true},
+		{"https://API.Todoist.com/file", true},
+		{"http://files.todoist.com/file", false},
+		{"https://eviltodoist.com/file", false},
+		{"https://todoist.com.example.com/file", false},
+		{"https://example.com/todoist.com", false},
+		{"https://api.todoist.com@example.com/file", false},
+		{"https://api.todoist.com/sync/v9/file", true},
+		{"http://127.0.0.1:8080/file", true},
+		{"http://127.0.0.1:8081/file", false},
+		{"https://127.0.0.1:8080/file", false},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.trustedHost(u); got != tt.want {
+			t.Errorf("trustedHost(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+// fileServer serves a file at every path and records the Authorization header it was requested with.
+func fileServer(t *testing.T, auth *string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*auth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("hello"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadAttachmentToken(t *testing.T) {
+	var apiAuth, foreignAuth string
+	api := fileServer(t, &apiAuth)
+	foreign := fileServer(t, &foreignAuth)
+
+	// The API host redirects /redirect to the foreign host.
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiAuth = r.Header.Get("Authorization")
+		http.Redirect(w, r, foreign.URL+"/file", http.StatusFound)
+	}))
+	t.Cleanup(redirecting.Close)
+
+	tests := []struct {
+		name              string
+		baseURL, fileURL  string
+		wantAPI, wantElse string
+	}{
+		{"API host", api.URL, api.URL + "/file", "Bearer token", ""},
+		{"foreign host", api.URL, foreign.URL + "/file", "", ""},
+		{"redirect to foreign host", redirecting.URL, redirecting.URL + "/redirect", "Bearer token", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiAuth, foreignAuth = "", ""
+			c := NewTodoistClient("token")
+			c.BaseURL = tt.baseURL
+
+			var buf bytes.Buffer
+			attachment := &Attachment{FileURL: tt.fileURL, FileType: "text/plain", FileSize: 5}
+			if _, err := c.DownloadAttachment(context.Background(), attachment, &buf); err != nil {
+				t.Fatalf("DownloadAttachment: %v", err)
+			}
+			if buf.String() != "hello" {
+				t.Errorf("downloaded %q, want hello", buf.String())
+			}
+			if apiAuth != tt.wantAPI {
+				t.Errorf("API host saw Authorization %q, want %q", apiAuth, tt.wantAPI)
+			}
+			if foreignAuth != tt.wantElse {
+				t.Errorf("foreign host saw Authorization %q", foreignAuth)
+			}
+		})
+	}
+}
+
+func TestDownloadAttachmentMismatch(t *testing.T) {
+	var auth string
+	server := fileServer(t, &auth)
+	c := NewTodoistClient("token")
+	c.BaseURL = server.URL
+
+	tests := []struct {
+		name       string
+		attachment Attachment
+	}{
+		{"content type", Attachment{FileType: "image/png"}},
+		{"size", Attachment{FileSize: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attachment.FileURL = server.URL + "/file"
+			_, err := c.DownloadAttachment(context.Background(), &tt.attachment, &bytes.Buffer{})
+			if !errors.Is(err, ErrAttachmentMismatch) {
+				t.Errorf("DownloadAttachment = %v, want ErrAttachmentMismatch", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package todoist
+
+import (
+	"time"
+)
+
+// DurationUnit is the unit of a task duration, either "minute" or "day".
+type DurationUnit string
+
+const (
+	DurationUnitMinute DurationUnit = "minute"
+	DurationUnitDay    DurationUnit = "day"
+)
+
+// DefaultWorkingDay is the length of a day used for conversions when no working day length is given.
+const DefaultWorkingDay = 8 * time.Hour
+
+// IsValid reports whether u is a unit accepted by the API.
+func (u DurationUnit) IsValid() bool {
+	return u == DurationUnitMinute || u == DurationUnitDay
+}
+
+// ToDuration converts the task duration to a time.Duration, counting a day as workingDay.
+// A zero workingDay uses DefaultWorkingDay, pass 24 * time.Hour to count calendar days.
+func (d TaskDuration) ToDuration(workingDay time.Duration) time.Duration {
+	switch d.Unit {
+	case DurationUnitMinute:
+		return time.Duration(d.Amount) * time.Minute
+	case DurationUnitDay:
+		return time.Duration(d.Amount) * workingDayOrDefault(workingDay)
+	}
+	return 0
+}
+
+// NewTaskDuration converts d to a TaskDuration in the given unit, rounding up to whole units.
+// A zero workingDay uses DefaultWorkingDay. A d of zero or less returns the zero TaskDuration.
+func NewTaskDuration(d time.Duration, unit DurationUnit, workingDay time.Duration) TaskDuration {
+	if d <= 0 {
+		return TaskDuration{}
+	}
+
+	size := time.Minute
+	if unit == DurationUnitDay {
+		size = workingDayOrDefault(workingDay)
+	}
+
+	amount := int(d / size)
+	if d%size != 0 {
+		amount++
+	}
+	return TaskDuration{Amount: amount, Unit: unit}
+}
+
+// WithDuration returns a copy of the task parameters with the duration set to d in whole minutes.
+// A d of zero or less leaves the duration unset.
+func (p TaskParams) WithDuration(d time.Duration) TaskParams {
+	if d <= 0 {
+		p.Duration, p.DurationUnit = 0, ""
+		return p
+	}
+	td := NewTaskDuration(d, DurationUnitMinute, 0)
+	p.Duration = td.Amount
+	p.DurationUnit = td.Unit
+	return p
+}
+
+// WithDurationDays returns a copy of the task parameters with the duration set to a number of days.
+// A number of zero or less leaves the duration unset.
+func (p TaskParams) WithDurationDays(days int) TaskParams {
+	if days <= 0 {
+		p.Duration, p.DurationUnit = 0, ""
+		return p
+	}
+	p.Duration = days
+	p.DurationUnit = DurationUnitDay
+	return p
+}
+
+// TotalDuration sums the estimated durations of tasks; tasks without a duration are ignored.
+func TotalDuration(tasks []Task, workingDay time.Duration) time.Duration {
+	var total time.Duration
+	for _, task := range tasks {
+		total += taskDuration(task, workingDay)
+	}
+	return total
+}
+
+// DurationByProject sums the estimated durations of tasks per project ID.
+func DurationByProject(tasks []Task, workingDay time.Duration) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	for _, task := range tasks {
+		if task.Duration != nil {
+			totals[task.ProjectID] += taskDuration(task, workingDay)
+		}
+	}
+	return totals
+}
+
+// DurationBySection sums the estimated durations of tasks per section ID.
+// Tasks outside of a section are counted under "".
+func DurationBySection(tasks []Task, workingDay time.Duration) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	for _, task := range tasks {
+		if task.Duration != nil {
+			totals[task.SectionID] += taskDuration(task, workingDay)
+		}
+	}
+	return totals
+}
+
+// DurationByLabel sums the estimated durations of tasks per label name.
+// A task with several labels is counted in full under each of them.
+func DurationByLabel(tasks []Task, workingDay time.Duration) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	for _, task := range tasks {
+		if task.Duration == nil {
+			continue
+		}
+		for _, label := range task.Labels {
+			totals[label] += taskDuration(task, workingDay)
+		}
+	}
+	return totals
+}
+
+// taskDuration returns the duration of a task, or 0 if it has none.
+func taskDuration(task Task, workingDay time.Duration) time.Duration {
+	if task.Duration == nil {
+		return 0
+	}
+	return task.Duration.ToDuration(workingDay)
+}
+
+// workingDayOrDefault returns workingDay, falling back to DefaultWorkingDay if it is not positive.
+func workingDayOrDefault(workingDay time.Duration) time.Duration {
+	if workingDay <= 0 {
+		return DefaultWorkingDay
+	}
+	return workingDay
+}
@@ -0,0 +1,46 @@
+package todoist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the Todoist API responds with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Message    string // Response body as sent by the API, if any
+	action     string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API request failed with status code: %d", e.StatusCode)
+	if e.action != "" {
+		msg = fmt.Sprintf("failed to %s, status code: %d", e.action, e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// newAPIError reads the response body into an APIError and closes it.
+func newAPIError(resp *http.Response, action string) *APIError {
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+		action:     action,
+	}
+}
+
+// checkNoContent closes the response body and returns an APIError unless the status is 204 No Content.
+func checkNoContent(resp *http.Response, action string) error {
+	if resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, action)
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,141 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateRef is a date of a query, resolved against today when tasks are matched.
+type dateRef func(today time.Time) time.Time
+
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseDate reads the dates of queries: "today", "tomorrow", "yesterday", offsets such as
+// "+3 days" or "-2 weeks", "2026-11-02", "Nov 2", "2 November 2026" and weekdays. Dates without
+// a year and weekdays refer to their next occurrence, today included.
+func parseDate(s string) (dateRef, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	switch lower {
+	case "":
+		return nil, fmt.Errorf("missing date")
+	case "today":
+		return func(today time.Time) time.Time { return today }, nil
+	case "tomorrow":
+		return func(today time.Time) time.Time { return today.AddDate(0, 0, 1) }, nil
+	case "yesterday":
+		return func(today time.Time) time.Time { return today.AddDate(0, 0, -1) }, nil
+	}
+
+	if ref, ok := parseOffset(lower); ok {
+		return ref, nil
+	}
+	if t, err := time.Parse(time.DateOnly, lower); err == nil {
+		return func(today time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, today.Location())
+		}, nil
+	}
+	if wd, ok := lookup(weekdays, lower); ok {
+		return func(today time.Time) time.Time {
+			return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7)
+		}, nil
+	}
+	if ref, ok := parseDayMonth(lower); ok {
+		return ref, nil
+	}
+	return nil, fmt.Errorf("invalid date %q", s)
+}
+
+// parseOffset reads "+3 days", "-1 week" and "in 2 days".
+func parseOffset(lower string) (dateRef, bool) {
+	sign := 1
+	switch {
+	case strings.HasPrefix(lower, "+"):
+		lower = lower[1:]
+	case strings.HasPrefix(lower, "-"):
+		sign, lower = -1, lower[1:]
+	case strings.HasPrefix(lower, "in "):
+		lower = lower[3:]
+	default:
+		return nil, false
+	}
+
+	count, unit, ok := strings.Cut(strings.TrimSpace(lower), " ")
+	n, err := strconv.Atoi(count)
+	if !ok || err != nil {
+		return nil, false
+	}
+	switch strings.TrimSpace(unit) {
+	case "day", "days":
+	case "week", "weeks":
+		n *= 7
+	default:
+		return nil, false
+	}
+	days := sign * n
+	return func(today time.Time) time.Time { return today.AddDate(0, 0, days) }, true
+}
+
+// parseDayMonth reads "Nov 2", "November 2, 2026", "2 Nov" and "2 November 2026".
+func parseDayMonth(lower string) (dateRef, bool) {
+	fields := strings.Fields(strings.ReplaceAll(lower, ",", " "))
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, false
+	}
+
+	monthField, dayField := fields[0], fields[1]
+	if _, err := strconv.Atoi(monthField); err == nil {
+		monthField, dayField = dayField, monthField
+	}
+	month, ok := lookup(months, monthField)
+	if !ok {
+		return nil, false
+	}
+	d, err := strconv.Atoi(dayField)
+	if err != nil || d < 1 || d > 31 {
+		return nil, false
+	}
+	year := 0
+	if len(fields) == 3 {
+		if year, err = strconv.Atoi(fields[2]); err != nil || year < 1000 {
+			return nil, false
+		}
+	}
+
+	return func(today time.Time) time.Time {
+		if year != 0 {
+			return time.Date(year, month, d, 0, 0, 0, 0, today.Location())
+		}
+		t := time.Date(today.Year(), month, d, 0, 0, 0, 0, today.Location())
+		if t.Before(today) {
+			t = t.AddDate(1, 0, 0)
+		}
+		return t
+	}, true
+}
+
+// lookup finds a month or weekday by its name or a prefix of at least three letters.
+func lookup[T any](names map[string]T, word string) (T, bool) {
+	var zero T
+	if len(word) < 3 {
+		return zero, false
+	}
+	v, ok := names[word[:3]]
+	if !ok {
+		return zero, false
+	}
+	if !strings.HasPrefix(strings.ToLower(fmt.Sprint(v)), word) {
+		return zero, false
+	}
+	return v, true
+}
@@ -0,0 +1,217 @@
+// Package filter evaluates Todoist's filter query language locally, over tasks that were already
+// fetched or cached.
+//
+// Queries combine filters with & (and), | (or), ! (not) and parentheses:
+//
+//	(today | overdue) & #Work
+//	p1 & ##Clients & !/Done
+//	@urgent & !assigned
+//	due before: +3 days & search: invoice
+//
+// Queries separated by commas, such as "today, overdue", are shown as separate lists by Todoist.
+// Here they match the tasks matching any of them, like | with the lowest precedence.
+//
+// The supported filters are:
+//
+//	#Project, ##Project      tasks of a project, ## including its subprojects
+//	/Section                 tasks in a section of that name, /* in any section
+//	@label, no labels        tasks with a label, or without any
+//	p1 to p4, priority 1     tasks of a priority
+//	today, tomorrow, yesterday, overdue (od), no date, no time, recurring
+//	N days, next N days      tasks due from today to N-1 days from now
+//	due: D, due before: D, due after: D (also date:, date before: and date after:)
+//	D                        tasks due on a date
+//	assigned, assigned to: me|others|NAME, assigned by: me|NAME
+//	shared, subtask, search: text, all
+//
+// Dates D are "today", "tomorrow", "yesterday", "+3 days", "-1 week", "2026-11-02", "Nov 2",
+// "2 November 2026" or a weekday, which means its next occurrence. Names may contain * as a
+// wildcard and are matched case-insensitively. Operator characters in names and dates, including
+// commas, are escaped with a backslash, as in "#R\&D" or "due: Nov 2\, 2026".
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Filter is a parsed query.
+type Filter struct {
+	query string
+	root  node
+}
+
+// SyntaxError reports a query that cannot be parsed.
+type SyntaxError struct {
+	Query string
+	// Pos is the byte offset in Query the error was found at.
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("filter: %s at position %d of %q", e.Msg, e.Pos, e.Query)
+}
+
+// Parse parses a query.
+func Parse(query string) (*Filter, error) {
+	p := &parser{query: query}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{query: query, root: root}, nil
+}
+
+// MustParse is like Parse but panics if the query cannot be parsed.
+func MustParse(query string) *Filter {
+	f, err := Parse(query)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// String returns the query the filter was parsed from.
+func (f *Filter) String() string {
+	return f.query
+}
+
+// Env holds what filters need to know beyond the tasks themselves.
+type Env struct {
+	// Projects and Sections resolve the project and section filters.
+	Projects []todoist.Project
+	Sections []todoist.Section
+	// Me is the ID of the current user, for "assigned to: me" and "assigned by: me".
+	Me string
+	// Users maps user IDs to names, for filters such as "assigned to: Alice".
+	Users map[string]string
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+	// Location is the timezone that decides which day a due time falls on. Defaults to time.Local.
+	Location *time.Location
+}
+
+// Match reports whether a task matches the filter. Use Apply to filter many tasks at once.
+func (f *Filter) Match(task todoist.Task, env *Env) bool {
+	return f.root.match(&task, newContext(env))
+}
+
+// Apply returns the tasks matching the filter, in their order.
+func (f *Filter) Apply(tasks []todoist.Task, env *Env) []todoist.Task {
+	ctx := newContext(env)
+	var matched []todoist.Task
+	for i := range tasks {
+		if f.root.match(&tasks[i], ctx) {
+			matched = append(matched, tasks[i])
+		}
+	}
+	return matched
+}
+
+// context is an Env prepared for matching.
+type context struct {
+	env      *Env
+	loc      *time.Location
+	now      time.Time
+	today    time.Time
+	projects map[string]*todoist.Project
+	sections map[string]*todoist.Section
+}
+
+func newContext(env *Env) *context {
+	if env == nil {
+		env = &Env{}
+	}
+	ctx := &context{
+		env:      env,
+		loc:      env.Location,
+		projects: make(map[string]*todoist.Project, len(env.Projects)),
+		sections: make(map[string]*todoist.Section, len(env.Sections)),
+	}
+	if ctx.loc == nil {
+		ctx.loc = time.Local
+	}
+	if env.Now != nil {
+		ctx.now = env.Now().In(ctx.loc)
+	} else {
+		ctx.now = time.Now().In(ctx.loc)
+	}
+	ctx.today = day(ctx.now)
+	for i := range env.Projects {
+		ctx.projects[env.Projects[i].ID] = &env.Projects[i]
+	}
+	for i := range env.Sections {
+		ctx.sections[env.Sections[i].ID] = &env.Sections[i]
+	}
+	return ctx
+}
+
+// underProject reports whether a project is the project with the given ID or one of its subprojects.
+func (ctx *context) underProject(projectID string, match func(*todoist.Project) bool) bool {
+	for depth := 0; depth <= len(ctx.projects); depth++ {
+		p, ok := ctx.projects[projectID]
+		if !ok {
+			return false
+		}
+		if match(p) {
+			return true
+		}
+		if p.ParentID == nil {
+			return false
+		}
+		projectID = *p.ParentID
+	}
+	return false
+}
+
+// due returns the day a task is due, at midnight in the context's timezone, and the exact
+// time for tasks due at a time.
+func (ctx *context) due(t *todoist.Task) (date, at time.Time, timed, ok bool) {
+	if t.Due == nil {
+		return time.Time{}, time.Time{}, false, false
+	}
+	if t.Due.Datetime != "" {
+		if at, err := time.Parse(time.RFC3339, t.Due.Datetime); err == nil {
+			at = at.In(ctx.loc)
+			return day(at), at, true, true
+		}
+		if at, err := time.ParseInLocation("2006-01-02T15:04:05", t.Due.Datetime, ctx.loc); err == nil {
+			return day(at), at, true, true
+		}
+	}
+	d, err := time.ParseInLocation(time.DateOnly, t.Due.Date, ctx.loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, false
+	}
+	return d, time.Time{}, false, true
+}
+
+// day returns midnight of the day of t, in t's location.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// matchName reports whether name matches a pattern, ignoring case, where * matches any text.
+func matchName(pattern, name string) bool {
+	pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
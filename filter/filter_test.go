@@ -0,0 +1,219 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// now is Monday, 19 October 2026, 10:00 UTC.
+var now = time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+func testEnv() *Env {
+	work := "1"
+	return &Env{
+		Projects: []todoist.Project{
+			{ID: "1", Name: "Work"},
+			{ID: "2", Name: "Clients", ParentID: &work},
+			{ID: "3", Name: "Home"},
+			{ID: "4", Name: "R&D", IsShared: true},
+		},
+		Sections: []todoist.Section{
+			{ID: "10", ProjectID: "1", Name: "Done"},
+		},
+		Me:       "u1",
+		Users:    map[string]string{"u1": "Me", "u2": "Alice"},
+		Now:      func() time.Time { return now },
+		Location: time.UTC,
+	}
+}
+
+// testTasks are named a to f, by their IDs.
+var testTasks = []todoist.Task{
+	{ID: "a", ProjectID: "1", Content: "Send invoice", Priority: todoist.PriorityP1, Labels: []string{"urgent"},
+		Due: &todoist.TaskDue{Date: "2026-10-19"}},
+	{ID: "b", ProjectID: "2", Content: "Call client", Priority: todoist.PriorityP2, AssigneeID: "u2", AssignerID: "u1",
+		Due: &todoist.TaskDue{Date: "2026-10-18"}},
+	{ID: "c", ProjectID: "3", Content: "Water plants", Priority: todoist.PriorityP4, ParentID: "f"},
+	{ID: "d", ProjectID: "1", SectionID: "10", Content: "Review report", Priority: todoist.PriorityP3, Labels: []string{"waiting"},
+		Due: &todoist.TaskDue{Date: "2026-10-19", Datetime: "2026-10-19T09:00:00Z"}},
+	{ID: "e", ProjectID: "4", Content: "Weekly sync", Priority: todoist.PriorityP4, AssigneeID: "u1", Labels: []string{"urgent-ish"},
+		Due: &todoist.TaskDue{Date: "2026-10-20", IsRecurring: true}},
+	{ID: "f", ProjectID: "3", Content: "Renew R&D domain", Priority: todoist.PriorityP4,
+		Due: &todoist.TaskDue{Date: "2026-11-02"}},
+}
+
+// matching applies a query to the test tasks and returns the IDs of the matches.
+func matching(t *testing.T, query string, env *Env) string {
+	t.Helper()
+
+	f, err := Parse(query)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", query, err)
+	}
+	var ids []string
+	for _, task := range f.Apply(testTasks, env) {
+		ids = append(ids, task.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"all", "a b c d e f"},
+		{"view all", "a b c d e f"},
+		{"today", "a d"},
+		{"tomorrow", "e"},
+		{"yesterday", "b"},
+		{"overdue", "b d"},
+		{"OD", "b d"},
+		{"no date", "c"},
+		{"no due date", "c"},
+		{"no time", "a b e f"},
+		{"recurring", "e"},
+		{"no labels", "b c f"},
+		{"assigned", "b e"},
+		{"assigned to: me", "e"},
+		{"assigned to: others", "b"},
+		{"assigned to: alice", "b"},
+		{"assigned to: u2", "b"},
+		{"assigned by: me", "b"},
+		{"subtask", "c"},
+		{"shared", "e"},
+		{"search: INVOICE", "a"},
+		{"p1", "a"},
+		{"priority 2", "b"},
+		{"P4", "c e f"},
+		{"#Work", "a d"},
+		{"#work", "a d"},
+		{"##Work", "a b d"},
+		{"#C*", "b"},
+		{"/Done", "d"},
+		{"/*", "d"},
+		{"@urgent", "a"},
+		{"@urg*", "a e"},
+		{"3 days", "a d e"},
+		{"next 3 days", "a d e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := matching(t, tt.query, testEnv()); got != tt.want {
+				t.Errorf("%q matched %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDates(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"2026-11-02", "f"},
+		{"Nov 2", "f"},
+		{"2 November 2026", "f"},
+		{"due: nov 2", "f"},
+		{"date: +14 days", "f"},
+		{"due: in 2 weeks", "f"},
+		{"due before: tomorrow", "a b d"},
+		{"date before: today", "b"},
+		{"due after: today", "e f"},
+		{"date after: -1 week", "a b d e f"},
+		{"due: yesterday", "b"},
+		{"monday", "a d"},
+		{"mon", "a d"},
+		{"tue", "e"},
+		// Dates without a year are the next occurrence, so yesterday's date is next year's.
+		{"due: Oct 18", ""},
+		{"due: Oct 18 2026", "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := matching(t, tt.query, testEnv()); got != tt.want {
+				t.Errorf("%q matched %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocation(t *testing.T) {
+	env := testEnv()
+	// At 10:00 UTC it is still 19 October in Honolulu, but task d was due at 23:00 the day before.
+	env.Location = time.FixedZone("HST", -10*60*60)
+
+	if got := matching(t, "yesterday", env); got != "b d" {
+		t.Errorf("yesterday in HST matched %q, want \"b d\"", got)
+	}
+}
+
+func TestOperators(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		// & binds tighter than |, and , is lowest.
+		{"p1 | p2 & overdue", "a b"},
+		{"p2 & overdue | p1", "a b"},
+		{"(p1 | p2) & overdue", "b"},
+		{"p1 & today, p2", "a b"},
+		{"p4, p1 & today", "a c e f"},
+		{"today, overdue", "a b d"},
+		{"today,overdue", "a b d"},
+		{"!p4", "a b d"},
+		{"!!p4", "c e f"},
+		{"!p4 & !overdue", "a"},
+		{"!(p1 | p2)", "c d e f"},
+		{"! (today | no date)", "b e f"},
+		// Escaped operators are part of names and dates.
+		{`#R\&D`, "e"},
+		{`search: R\&D`, "f"},
+		{`due: Nov 2\, 2026`, "f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := matching(t, tt.query, testEnv()); got != tt.want {
+				t.Errorf("%q matched %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{"", 0, "missing filter at end of query"},
+		{"p1 &", 4, "missing filter at end of query"},
+		{"& p1", 0, "missing filter before '&'"},
+		{"(p1", 3, "missing )"},
+		{"p1)", 2, "unexpected ')'"},
+		{"today,", 6, "missing filter at end of query"},
+		{"(today, overdue)", 6, ", separates whole queries"},
+		{"p5", 0, `unknown filter "p5"`},
+		{"#", 0, "missing project name"},
+		{"#R&D", 3, `unknown filter "D"`},
+		{"due: someday", 0, `invalid date "someday"`},
+		{"assigned to:", 0, "missing user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) = %v, want a SyntaxError", tt.query, err)
+			}
+			if syntaxErr.Pos != tt.pos || !strings.Contains(syntaxErr.Msg, tt.msg) {
+				t.Errorf("Parse(%q) = %v, want %q at position %d", tt.query, err, tt.msg, tt.pos)
+			}
+		})
+	}
+}
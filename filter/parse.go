@@ -0,0 +1,155 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parser is a recursive descent parser of queries:
+//
+//	list  = or { "," or }
+//	or    = and { "|" and }
+//	and   = unary { "&" unary }
+//	unary = "!" unary | "(" or ")" | term
+type parser struct {
+	query string
+	pos   int
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.list()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.query) {
+		return nil, p.errorf("unexpected %q", p.query[p.pos])
+	}
+	return n, nil
+}
+
+// list reads comma separated queries, which Todoist shows as separate lists. Here a task
+// matches if it matches any of them.
+func (p *parser) list() (node, error) {
+	left, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume(',') {
+		right, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume('|') {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume('&') {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	switch {
+	case p.consume('!'):
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return not{n}, nil
+	case p.consume('('):
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.consume(',') {
+			p.pos--
+			return nil, p.errorf(", separates whole queries and cannot be used inside parentheses")
+		}
+		if !p.consume(')') {
+			return nil, p.errorf("missing )")
+		}
+		return n, nil
+	}
+	return p.term()
+}
+
+// term reads the text up to the next operator and parses it as a filter.
+func (p *parser) term() (node, error) {
+	p.skipSpace()
+	start := p.pos
+	var b strings.Builder
+	for p.pos < len(p.query) {
+		c := p.query[p.pos]
+		if c == '\\' && p.pos+1 < len(p.query) {
+			b.WriteByte(p.query[p.pos+1])
+			p.pos += 2
+			continue
+		}
+		if strings.IndexByte("&|(),", c) >= 0 {
+			break
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	text := strings.TrimSpace(b.String())
+	if text == "" {
+		p.pos = start
+		if p.pos == len(p.query) {
+			return nil, p.errorf("missing filter at end of query")
+		}
+		return nil, p.errorf("missing filter before %q", p.query[p.pos])
+	}
+	n, err := parseTerm(text)
+	if err != nil {
+		return nil, &SyntaxError{Query: p.query, Pos: start, Msg: err.Error()}
+	}
+	return n, nil
+}
+
+// consume skips spaces and the byte c if it comes next, reporting whether it did.
+func (p *parser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.query) && p.query[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.query) && (p.query[p.pos] == ' ' || p.query[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Query: p.query, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,281 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// node is a parsed filter or combination of filters.
+type node interface {
+	match(t *todoist.Task, ctx *context) bool
+}
+
+type and struct{ left, right node }
+
+func (n and) match(t *todoist.Task, ctx *context) bool {
+	return n.left.match(t, ctx) && n.right.match(t, ctx)
+}
+
+type or struct{ left, right node }
+
+func (n or) match(t *todoist.Task, ctx *context) bool {
+	return n.left.match(t, ctx) || n.right.match(t, ctx)
+}
+
+type not struct{ node node }
+
+func (n not) match(t *todoist.Task, ctx *context) bool {
+	return !n.node.match(t, ctx)
+}
+
+// predicate is a filter without operators.
+type predicate func(t *todoist.Task, ctx *context) bool
+
+func (p predicate) match(t *todoist.Task, ctx *context) bool {
+	return p(t, ctx)
+}
+
+// keywords are the filters that take no argument.
+var keywords = map[string]predicate{
+	"all":       func(*todoist.Task, *context) bool { return true },
+	"view all":  func(*todoist.Task, *context) bool { return true },
+	"today":     onDay(0),
+	"tomorrow":  onDay(1),
+	"yesterday": onDay(-1),
+	"overdue":   overdue,
+	"od":        overdue,
+	"no date": func(t *todoist.Task, ctx *context) bool {
+		_, _, _, ok := ctx.due(t)
+		return !ok
+	},
+	"no due date": func(t *todoist.Task, ctx *context) bool {
+		_, _, _, ok := ctx.due(t)
+		return !ok
+	},
+	"no time": func(t *todoist.Task, ctx *context) bool {
+		_, _, timed, ok := ctx.due(t)
+		return ok && !timed
+	},
+	"recurring": func(t *todoist.Task, _ *context) bool { return t.Due != nil && t.Due.IsRecurring },
+	"no labels": func(t *todoist.Task, _ *context) bool { return len(t.Labels) == 0 },
+	"assigned":  func(t *todoist.Task, _ *context) bool { return t.AssigneeID != "" },
+	"subtask":   func(t *todoist.Task, _ *context) bool { return t.ParentID != "" },
+	"shared": func(t *todoist.Task, ctx *context) bool {
+		p, ok := ctx.projects[t.ProjectID]
+		return ok && p.IsShared
+	},
+}
+
+// parseTerm parses the text of a filter without operators.
+func parseTerm(text string) (node, error) {
+	lower := strings.ToLower(text)
+	if p, ok := keywords[lower]; ok {
+		return p, nil
+	}
+
+	switch {
+	case strings.HasPrefix(text, "##"):
+		return projectFilter(text[2:], true)
+	case strings.HasPrefix(text, "#"):
+		return projectFilter(text[1:], false)
+	case strings.HasPrefix(text, "/"):
+		return sectionFilter(text[1:])
+	case strings.HasPrefix(text, "@"):
+		return labelFilter(text[1:])
+	}
+
+	if name, value, ok := cutPrefix(text, "search:"); ok {
+		return searchFilter(name, value)
+	}
+	for _, prefix := range []string{"due before:", "date before:"} {
+		if _, value, ok := cutPrefix(text, prefix); ok {
+			return dateFilter(value, func(d, ref time.Time) bool { return d.Before(ref) })
+		}
+	}
+	for _, prefix := range []string{"due after:", "date after:"} {
+		if _, value, ok := cutPrefix(text, prefix); ok {
+			return dateFilter(value, func(d, ref time.Time) bool { return d.After(ref) })
+		}
+	}
+	for _, prefix := range []string{"due:", "date:"} {
+		if _, value, ok := cutPrefix(text, prefix); ok {
+			return dateFilter(value, func(d, ref time.Time) bool { return d.Equal(ref) })
+		}
+	}
+	if _, value, ok := cutPrefix(text, "assigned to:"); ok {
+		return assignedFilter(value, func(t *todoist.Task) string { return t.AssigneeID }, true)
+	}
+	if _, value, ok := cutPrefix(text, "assigned by:"); ok {
+		return assignedFilter(value, func(t *todoist.Task) string { return t.AssignerID }, false)
+	}
+
+	if p, ok := priorityFilter(lower); ok {
+		return p, nil
+	}
+	if p, ok := daysFilter(lower); ok {
+		return p, nil
+	}
+	if ref, err := parseDate(text); err == nil {
+		return onDate(ref, func(d, ref time.Time) bool { return d.Equal(ref) }), nil
+	}
+	return nil, fmt.Errorf("unknown filter %q", text)
+}
+
+// cutPrefix removes a case-insensitive prefix and the spaces after it.
+func cutPrefix(text, prefix string) (string, string, bool) {
+	if len(text) < len(prefix) || !strings.EqualFold(text[:len(prefix)], prefix) {
+		return "", "", false
+	}
+	return prefix, strings.TrimSpace(text[len(prefix):]), true
+}
+
+func projectFilter(pattern string, subprojects bool) (node, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("missing project name")
+	}
+	matches := func(p *todoist.Project) bool { return matchName(pattern, p.Name) }
+	return predicate(func(t *todoist.Task, ctx *context) bool {
+		if subprojects {
+			return ctx.underProject(t.ProjectID, matches)
+		}
+		p, ok := ctx.projects[t.ProjectID]
+		return ok && matches(p)
+	}), nil
+}
+
+func sectionFilter(pattern string) (node, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("missing section name")
+	}
+	return predicate(func(t *todoist.Task, ctx *context) bool {
+		s, ok := ctx.sections[t.SectionID]
+		if !ok {
+			// Without the section, only whether the task is in one is known.
+			return t.SectionID != "" && pattern == "*"
+		}
+		return matchName(pattern, s.Name)
+	}), nil
+}
+
+func labelFilter(pattern string) (node, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, fmt.Errorf("missing label name")
+	}
+	return predicate(func(t *todoist.Task, _ *context) bool {
+		for _, label := range t.Labels {
+			if matchName(pattern, label) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func searchFilter(prefix, text string) (node, error) {
+	if text == "" {
+		return nil, fmt.Errorf("missing text after %s", prefix)
+	}
+	text = strings.ToLower(text)
+	return predicate(func(t *todoist.Task, _ *context) bool {
+		return strings.Contains(strings.ToLower(t.Content), text)
+	}), nil
+}
+
+// priorityFilter reads "p1" to "p4" and "priority 1" to "priority 4".
+func priorityFilter(lower string) (node, bool) {
+	if rest, ok := strings.CutPrefix(lower, "priority "); ok {
+		lower = "p" + strings.TrimSpace(rest)
+	}
+	if len(lower) != 2 || lower[0] != 'p' {
+		return nil, false
+	}
+	priority, err := todoist.ParsePriority(lower)
+	if err != nil {
+		return nil, false
+	}
+	return predicate(func(t *todoist.Task, _ *context) bool { return t.Priority == priority }), true
+}
+
+// daysFilter reads "N days" and "next N days", the tasks due from today to N-1 days from now.
+func daysFilter(lower string) (node, bool) {
+	lower = strings.TrimPrefix(lower, "next ")
+	count, unit, ok := strings.Cut(lower, " ")
+	if !ok || (unit != "days" && unit != "day") {
+		return nil, false
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return nil, false
+	}
+	return predicate(func(t *todoist.Task, ctx *context) bool {
+		d, _, _, ok := ctx.due(t)
+		return ok && !d.Before(ctx.today) && d.Before(ctx.today.AddDate(0, 0, n))
+	}), true
+}
+
+// onDay matches tasks due a number of days from today.
+func onDay(offset int) predicate {
+	return func(t *todoist.Task, ctx *context) bool {
+		d, _, _, ok := ctx.due(t)
+		return ok && d.Equal(ctx.today.AddDate(0, 0, offset))
+	}
+}
+
+// overdue matches tasks due before today, or earlier today for tasks due at a time.
+func overdue(t *todoist.Task, ctx *context) bool {
+	d, at, timed, ok := ctx.due(t)
+	if !ok {
+		return false
+	}
+	if timed {
+		return at.Before(ctx.now)
+	}
+	return d.Before(ctx.today)
+}
+
+func dateFilter(value string, cmp func(d, ref time.Time) bool) (node, error) {
+	ref, err := parseDate(value)
+	if err != nil {
+		return nil, err
+	}
+	return onDate(ref, cmp), nil
+}
+
+// onDate compares the due day of tasks with a date.
+func onDate(ref dateRef, cmp func(d, ref time.Time) bool) predicate {
+	return func(t *todoist.Task, ctx *context) bool {
+		d, _, _, ok := ctx.due(t)
+		return ok && cmp(d, ref(ctx.today))
+	}
+}
+
+// assignedFilter matches the user a task is assigned to or by: "me", "others" (only for the
+// assignee) or a user ID or name.
+func assignedFilter(who string, user func(*todoist.Task) string, others bool) (node, error) {
+	switch lower := strings.ToLower(who); {
+	case lower == "":
+		return nil, fmt.Errorf("missing user")
+	case lower == "me":
+		return predicate(func(t *todoist.Task, ctx *context) bool {
+			return ctx.env.Me != "" && user(t) == ctx.env.Me
+		}), nil
+	case lower == "others" && others:
+		return predicate(func(t *todoist.Task, ctx *context) bool {
+			return user(t) != "" && user(t) != ctx.env.Me
+		}), nil
+	}
+	return predicate(func(t *todoist.Task, ctx *context) bool {
+		id := user(t)
+		if id == "" {
+			return false
+		}
+		return id == who || matchName(who, ctx.env.Users[id])
+	}), nil
+}
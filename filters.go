@@ -0,0 +1,216 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Filter is a saved filter: a named query, such as "today & #Work", kept in the user's account.
+// Filters are only available through the Sync API.
+type Filter struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Query      string `json:"query"`
+	Color      Color  `json:"color"`
+	Order      int    `json:"item_order"`
+	IsFavorite bool   `json:"is_favorite"`
+	IsDeleted  bool   `json:"is_deleted,omitempty"`
+}
+
+// FilterParams defines the parameters for creating and updating a saved filter. Order and
+// IsFavorite are pointers so that updates can set them to zero and false.
+type FilterParams struct {
+	Name       string `json:"name,omitempty"`
+	Query      string `json:"query,omitempty"`
+	Color      Color  `json:"color,omitempty"`
+	Order      *int   `json:"item_order,omitempty"`
+	IsFavorite *bool  `json:"is_favorite,omitempty"`
+}
+
+// GetFilters fetches the saved filters of the user, in their order.
+func (c *TodoistClient) GetFilters() ([]Filter, error) {
+	return c.GetFiltersContext(context.Background())
+}
+
+// GetFiltersContext is like GetFilters but uses ctx for the request.
+func (c *TodoistClient) GetFiltersContext(ctx context.Context) ([]Filter, error) {
+	resp, err := c.SyncContext(ctx, "", ResourceFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]Filter, 0, len(resp.Filters))
+	for _, f := range resp.Filters {
+		if !f.IsDeleted {
+			filters = append(filters, f)
+		}
+	}
+	sort.SliceStable(filters, func(i, j int) bool { return filters[i].Order < filters[j].Order })
+	return filters, nil
+}
+
+// GetFilter fetches a saved filter by its ID. A missing filter is reported as an APIError
+// with status 404, like the other resources.
+func (c *TodoistClient) GetFilter(id string) (*Filter, error) {
+	return c.GetFilterContext(context.Background(), id)
+}
+
+// GetFilterContext is like GetFilter but uses ctx for the request.
+func (c *TodoistClient) GetFilterContext(ctx context.Context, id string) (*Filter, error) {
+	filters, err := c.GetFiltersContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range filters {
+		if filters[i].ID == id {
+			return &filters[i], nil
+		}
+	}
+	return nil, &APIError{StatusCode: http.StatusNotFound, Message: "filter not found", action: "get filter"}
+}
+
+// CreateFilter saves a new filter.
+func (c *TodoistClient) CreateFilter(params FilterParams) (*Filter, error) {
+	return c.CreateFilterContext(context.Background(), params)
+}
+
+// CreateFilterContext is like CreateFilter but uses ctx for the requests.
+func (c *TodoistClient) CreateFilterContext(ctx context.Context, params FilterParams) (*Filter, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	cmd := NewCommand("filter_add", params)
+	cmd.TempID = newUUID()
+	result, err := c.ExecuteCommandsContext(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := result.TempIDMapping[cmd.TempID]
+	if !ok {
+		return nil, fmt.Errorf("no ID returned for the new filter")
+	}
+	return c.GetFilterContext(ctx, id)
+}
+
+// UpdateFilter changes the fields of a saved filter that are set in params.
+func (c *TodoistClient) UpdateFilter(id string, params FilterParams) (*Filter, error) {
+	return c.UpdateFilterContext(context.Background(), id, params)
+}
+
+// UpdateFilterContext is like UpdateFilter but uses ctx for the requests.
+func (c *TodoistClient) UpdateFilterContext(ctx context.Context, id string, params FilterParams) (*Filter, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	args := struct {
+		ID string `json:"id"`
+		FilterParams
+	}{id, params}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("filter_update", args)); err != nil {
+		return nil, err
+	}
+	return c.GetFilterContext(ctx, id)
+}
+
+// DeleteFilter deletes a saved filter.
+func (c *TodoistClient) DeleteFilter(id string) (bool, error) {
+	return c.DeleteFilterContext(context.Background(), id)
+}
+
+// DeleteFilterContext is like DeleteFilter but uses ctx for the request.
+func (c *TodoistClient) DeleteFilterContext(ctx context.Context, id string) (bool, error) {
+	args := struct {
+		ID string `json:"id"`
+	}{id}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("filter_delete", args)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReorderFilters puts the listed saved filters in the order of ids. They take the positions they
+// held between them, so filters that are not listed keep their positions. All filters are
+// renumbered from 1, which keeps their orders unique.
+func (c *TodoistClient) ReorderFilters(ids []string) (bool, error) {
+	return c.ReorderFiltersContext(context.Background(), ids)
+}
+
+// ReorderFiltersContext is like ReorderFilters but uses ctx for the requests.
+func (c *TodoistClient) ReorderFiltersContext(ctx context.Context, ids []string) (bool, error) {
+	var errs ValidationErrors
+	if len(ids) == 0 {
+		errs.add("ids", "is required")
+	}
+	listed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if listed[id] {
+			errs.add("ids", fmt.Sprintf("contains %s more than once", id))
+		}
+		listed[id] = true
+	}
+	if err := errs.err(); err != nil {
+		return false, err
+	}
+
+	filters, err := c.GetFiltersContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if !containsFilter(filters, id) {
+			errs.add("ids", fmt.Sprintf("filter %s does not exist", id))
+		}
+	}
+	if err := errs.err(); err != nil {
+		return false, err
+	}
+
+	orders := make(map[string]int, len(filters))
+	next := 0
+	for i, f := range filters {
+		id := f.ID
+		if listed[id] {
+			id = ids[next]
+			next++
+		}
+		orders[id] = i + 1
+	}
+	args := struct {
+		IDOrderMapping map[string]int `json:"id_order_mapping"`
+	}{orders}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("filter_update_orders", args)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func containsFilter(filters []Filter, id string) bool {
+	for _, f := range filters {
+		if f.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFilterTasks runs a saved filter and returns the active tasks matching its query.
+func (c *TodoistClient) GetFilterTasks(id string) ([]Task, error) {
+	return c.GetFilterTasksContext(context.Background(), id)
+}
+
+// GetFilterTasksContext is like GetFilterTasks but uses ctx for the requests.
+func (c *TodoistClient) GetFilterTasksContext(ctx context.Context, id string) ([]Task, error) {
+	filter, err := c.GetFilterContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTasksByFilterContext(ctx, filter.Query)
+}
@@ -1,3 +1,11 @@
 module github.com/felixschmelzer/todoist-go
 
 go 1.23.2
+
+require (
+	golang.org/x/crypto v0.31.0
+	golang.org/x/term v0.27.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.28.0 // indirect
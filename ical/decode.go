@@ -0,0 +1,416 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is a VEVENT or VTODO component read from a calendar.
+type Entry struct {
+	Kind        Kind
+	UID         string
+	Summary     string
+	Description string
+	// Start is DTSTART, or DUE for to-dos without one. It is zero when the entry has neither.
+	Start time.Time
+	// End is DTEND or DUE, or Start plus DURATION. It is zero when the entry has no end.
+	End time.Time
+	// AllDay is set when Start is a date without a time.
+	AllDay bool
+	// Floating is set when Start is a local time without a timezone. Start is then in the location
+	// passed to Decode.
+	Floating   bool
+	Categories []string
+	// Priority is the iCalendar priority from 1, the highest, to 9, or 0 when undefined.
+	Priority int
+	Status   string
+	RRule    string
+}
+
+// property is a content line split into its parts.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// EntryError reports a VEVENT or VTODO that Decode could not read.
+type EntryError struct {
+	Line int    // Line the entry begins at
+	UID  string // UID of the entry, if it has one
+	Err  error
+}
+
+func (e EntryError) Error() string {
+	if e.UID != "" {
+		return fmt.Sprintf("entry %s at line %d: %v", e.UID, e.Line, e.Err)
+	}
+	return fmt.Sprintf("entry at line %d: %v", e.Line, e.Err)
+}
+
+func (e EntryError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrors lists the entries Decode skipped.
+type DecodeErrors []EntryError
+
+func (e DecodeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, entryErr := range e {
+		msgs[i] = entryErr.Error()
+	}
+	return "ical: skipped " + strings.Join(msgs, "; ")
+}
+
+// Decode reads the VEVENT and VTODO components of an iCalendar object. Floating times and
+// times in timezones unknown to the time package are read in loc, or UTC when loc is nil.
+//
+// Entries that cannot be read, such as ones with an invalid line or date, are skipped. Decode
+// returns the other entries together with DecodeErrors listing them. Invalid lines outside of
+// entries are ignored.
+func Decode(r io.Reader, loc *time.Location) ([]Entry, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	var errs DecodeErrors
+	var stack []string
+	var props []property
+	var begin int // Line the open entry begins at
+	var bad error // First error found in the open entry
+	calendar := false
+	inEntry := func() bool { return len(stack) >= 2 && isEntry(stack[:2]) }
+	skip := func(err error) {
+		e := EntryError{Line: begin, Err: err}
+		for _, p := range props {
+			if p.name == "UID" {
+				e.UID = p.value
+			}
+		}
+		errs = append(errs, e)
+	}
+
+	for i, line := range lines {
+		p, err := parseProperty(line)
+		if err != nil {
+			if inEntry() && bad == nil {
+				bad = fmt.Errorf("line %d: %w", i+1, err)
+			}
+			continue
+		}
+
+		switch p.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(p.value))
+			if isEntry(stack) {
+				props, begin, bad = props[:0], i+1, nil
+			}
+			calendar = calendar || stack[0] == "VCALENDAR"
+			continue
+		case "END":
+			depth := len(stack) - 1
+			for depth >= 0 && stack[depth] != strings.ToUpper(p.value) {
+				depth--
+			}
+			switch {
+			case depth < 0:
+				if inEntry() && bad == nil {
+					bad = fmt.Errorf("line %d: unexpected END:%s", i+1, p.value)
+				}
+			case depth == 1 && inEntry():
+				// Components left open inside the entry end with it.
+				e, err := newEntry(Kind(stack[1]), props, loc)
+				switch {
+				case bad != nil:
+					skip(bad)
+				case err != nil:
+					skip(err)
+				default:
+					entries = append(entries, e)
+				}
+				stack = stack[:1]
+			case depth == 0 && inEntry():
+				skip(fmt.Errorf("missing END:%s", stack[1]))
+				stack = stack[:0]
+			default:
+				stack = stack[:depth]
+			}
+			continue
+		}
+
+		// Properties of nested components such as VALARM are ignored.
+		if isEntry(stack) {
+			props = append(props, p)
+		}
+	}
+
+	if !calendar {
+		return nil, fmt.Errorf("ical: missing BEGIN:VCALENDAR")
+	}
+	if inEntry() {
+		skip(fmt.Errorf("missing END:%s", stack[1]))
+	}
+	if len(errs) > 0 {
+		return entries, errs
+	}
+	return entries, nil
+}
+
+// isEntry reports whether the innermost open component is a VEVENT or VTODO of a calendar.
+func isEntry(stack []string) bool {
+	if len(stack) != 2 || stack[0] != "VCALENDAR" {
+		return false
+	}
+	return stack[1] == string(Event) || stack[1] == string(Todo)
+}
+
+// unfold reads content lines, joining folded continuation lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case line == "":
+		case (line[0] == ' ' || line[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ical: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty splits a content line into name, parameters and value.
+func parseProperty(line string) (property, error) {
+	p := property{params: make(map[string]string)}
+
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return p, fmt.Errorf("invalid content line %q", line)
+	}
+	p.name = strings.ToUpper(line[:end])
+
+	rest := line[end:]
+	for rest != "" && rest[0] == ';' {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return p, fmt.Errorf("invalid parameter in %q", line)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			closing := strings.IndexByte(rest[1:], '"')
+			if closing < 0 {
+				return p, fmt.Errorf("unterminated quote in %q", line)
+			}
+			value, rest = rest[1:closing+1], rest[closing+2:]
+		} else {
+			stop := strings.IndexAny(rest, ";:")
+			if stop < 0 {
+				return p, fmt.Errorf("missing value in %q", line)
+			}
+			value, rest = rest[:stop], rest[stop:]
+		}
+		p.params[name] = value
+	}
+
+	if rest == "" || rest[0] != ':' {
+		return p, fmt.Errorf("missing value in %q", line)
+	}
+	p.value = rest[1:]
+	return p, nil
+}
+
+func newEntry(kind Kind, props []property, loc *time.Location) (Entry, error) {
+	e := Entry{Kind: kind}
+	var start, end, due *property
+	var duration string
+
+	for i := range props {
+		p := &props[i]
+		switch p.name {
+		case "UID":
+			e.UID = p.value
+		case "SUMMARY":
+			e.Summary = unescapeText(p.value)
+		case "DESCRIPTION":
+			e.Description = unescapeText(p.value)
+		case "DTSTART":
+			start = p
+		case "DTEND":
+			end = p
+		case "DUE":
+			due = p
+		case "DURATION":
+			duration = p.value
+		case "CATEGORIES":
+			for _, c := range splitText(p.value) {
+				if c = strings.TrimSpace(c); c != "" {
+					e.Categories = append(e.Categories, c)
+				}
+			}
+		case "PRIORITY":
+			e.Priority, _ = strconv.Atoi(p.value)
+		case "STATUS":
+			e.Status = strings.ToUpper(p.value)
+		case "RRULE":
+			e.RRule = p.value
+		}
+	}
+
+	if start == nil && kind == Todo {
+		start, due = due, nil
+	}
+	if end == nil {
+		end = due
+	}
+	if start == nil {
+		return e, nil
+	}
+
+	var err error
+	if e.Start, e.AllDay, e.Floating, err = parseTime(*start, loc); err != nil {
+		return e, err
+	}
+	switch {
+	case end != nil:
+		if e.End, _, _, err = parseTime(*end, loc); err != nil {
+			return e, err
+		}
+	case duration != "":
+		d, err := parseDuration(duration)
+		if err != nil {
+			return e, err
+		}
+		e.End = e.Start.Add(d)
+	}
+	return e, nil
+}
+
+// parseTime reads a DATE or DATE-TIME value, reporting whether it is a date and whether it is floating.
+func parseTime(p property, loc *time.Location) (t time.Time, allDay, floating bool, err error) {
+	v := p.value
+	switch {
+	case p.params["VALUE"] == "DATE" || len(v) == len(dateFormat):
+		t, err = time.ParseInLocation(dateFormat, v, loc)
+		return t, true, false, wrapTimeError(p, err)
+	case strings.HasSuffix(v, "Z"):
+		t, err = time.Parse(utcFormat, v)
+		return t, false, false, wrapTimeError(p, err)
+	}
+
+	if tzid := p.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			t, err = time.ParseInLocation(dateTimeFormat, v, zone)
+			return t, false, false, wrapTimeError(p, err)
+		}
+	}
+	t, err = time.ParseInLocation(dateTimeFormat, v, loc)
+	return t, false, true, wrapTimeError(p, err)
+}
+
+func wrapTimeError(p property, err error) error {
+	if err != nil {
+		return fmt.Errorf("invalid %s value %q", p.name, p.value)
+	}
+	return nil
+}
+
+// parseDuration reads a DURATION value such as "PT1H30M", "P2D" or "P1W".
+func parseDuration(v string) (time.Duration, error) {
+	s := v
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	s, ok := strings.CutPrefix(s, "P")
+	if !ok || s == "" {
+		return 0, fmt.Errorf("invalid duration %q", v)
+	}
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var d time.Duration
+	for s != "" {
+		if s[0] == 'T' {
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+			s = s[1:]
+			continue
+		}
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, fmt.Errorf("invalid duration %q", v)
+		}
+		n, _ := strconv.Atoi(s[:i])
+		unit, ok := units[s[i]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q", v)
+		}
+		d += time.Duration(n) * unit
+		s = s[i+1:]
+	}
+	return sign * d, nil
+}
+
+// unescapeText reverses escapeText. Unknown escapes keep the escaped character.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i == len(s)-1 {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// splitText splits a list of TEXT values at unescaped commas and unescapes them.
+func splitText(s string) []string {
+	var values []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, unescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, unescapeText(s[start:]))
+}
@@ -0,0 +1,84 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+	utcFormat      = "20060102T150405Z"
+)
+
+// moment is a due date or time as written to a calendar.
+type moment struct {
+	t        time.Time
+	allDay   bool
+	floating bool           // Local time without a timezone, the same wall clock in every timezone
+	loc      *time.Location // Set when t is written with a TZID, nil for UTC
+}
+
+// parseDue converts a task's due to a moment. Timed dues with a timezone are written in that
+// timezone unless utc is set; dues without one are floating.
+func parseDue(due *todoist.TaskDue, utc bool) (moment, error) {
+	if due.Datetime == "" {
+		if len(due.Date) < 10 {
+			return moment{}, fmt.Errorf("invalid due date %q", due.Date)
+		}
+		t, err := time.Parse(time.DateOnly, due.Date[:10])
+		if err != nil {
+			return moment{}, fmt.Errorf("invalid due date %q", due.Date)
+		}
+		return moment{t: t, allDay: true}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, due.Datetime); err == nil {
+		m := moment{t: t.UTC()}
+		if due.Timezone != "" && !utc {
+			if loc, err := time.LoadLocation(due.Timezone); err == nil && loc != time.UTC {
+				m.t, m.loc = t.In(loc), loc
+			}
+		}
+		return m, nil
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05", due.Datetime)
+	if err != nil {
+		return moment{}, fmt.Errorf("invalid due datetime %q", due.Datetime)
+	}
+	return moment{t: t, floating: true}, nil
+}
+
+// add returns the moment shifted by a task duration. Day durations count calendar days.
+func (m moment) add(d todoist.TaskDuration) moment {
+	if d.Unit == todoist.DurationUnitDay {
+		m.t = m.t.AddDate(0, 0, d.Amount)
+	} else {
+		m.t = m.in(m.t.Add(time.Duration(d.Amount) * time.Minute))
+	}
+	return m
+}
+
+// in keeps t in the moment's timezone after arithmetic.
+func (m moment) in(t time.Time) time.Time {
+	if m.loc != nil {
+		return t.In(m.loc)
+	}
+	return t
+}
+
+// prop formats the moment as the value of a date or date-time property.
+func (m moment) prop(name string) string {
+	switch {
+	case m.allDay:
+		return name + ";VALUE=DATE:" + m.t.Format(dateFormat)
+	case m.floating:
+		return name + ":" + m.t.Format(dateTimeFormat)
+	case m.loc != nil:
+		return name + ";TZID=" + paramValue(m.loc.String()) + ":" + m.t.Format(dateTimeFormat)
+	}
+	return name + ":" + m.t.UTC().Format(utcFormat)
+}
@@ -0,0 +1,172 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// priorities maps Todoist priorities to the iCalendar scale, where 1 is the highest and 9 the lowest.
+// P4 is Todoist's default and is left undefined.
+var priorities = map[todoist.Priority]string{
+	todoist.PriorityP1: "1",
+	todoist.PriorityP2: "3",
+	todoist.PriorityP3: "5",
+}
+
+// Encode writes the tasks as an iCalendar object. Tasks without a due date are skipped.
+//
+// Date-only dues become all-day entries, timed dues are written in the task's timezone with a
+// VTIMEZONE definition, or as floating times when the task has none. Recurring dues get an RRULE
+// when RecurrenceRule can translate them; the others are written as their next occurrence.
+func Encode(w io.Writer, tasks []todoist.Task, opts Options) error {
+	kind := opts.Kind
+	if kind == "" {
+		kind = Event
+	}
+	if kind != Event && kind != Todo {
+		return fmt.Errorf("ical: unsupported component %q", kind)
+	}
+	productID := opts.ProductID
+	if productID == "" {
+		productID = DefaultProductID
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	stamp := now().UTC().Format(utcFormat)
+
+	dues := make(map[string]moment, len(tasks))
+	zones := newZoneRange()
+	for _, t := range tasks {
+		if t.Due == nil {
+			continue
+		}
+		m, err := parseDue(t.Due, opts.UTC)
+		if err != nil {
+			return fmt.Errorf("ical: task %s: %w", t.ID, err)
+		}
+		dues[t.ID] = m
+		zones.add(m)
+	}
+
+	cw := newWriter(w)
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.prop("PRODID", productID)
+	cw.line("CALSCALE:GREGORIAN")
+	if opts.Name != "" {
+		cw.prop("NAME", opts.Name)
+		cw.prop("X-WR-CALNAME", opts.Name)
+	}
+	zones.write(cw)
+
+	for _, t := range tasks {
+		if m, ok := dues[t.ID]; ok {
+			writeTask(cw, kind, t, m, stamp, opts.EventDuration)
+		}
+	}
+
+	cw.line("END:VCALENDAR")
+	return cw.flush()
+}
+
+// Marshal returns the tasks as an iCalendar object. See Encode.
+func Marshal(tasks []todoist.Task, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, tasks, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTask(w *writer, kind Kind, t todoist.Task, start moment, stamp string, eventDuration time.Duration) {
+	end, hasEnd := endOf(t, start)
+	rule, recurring := "", false
+	if t.Due.IsRecurring {
+		rule, recurring = RecurrenceRule(t.Due.String)
+	}
+
+	w.line("BEGIN:" + string(kind))
+	w.line("UID:" + UID(t.ID))
+	w.line("DTSTAMP:" + stamp)
+	w.prop("SUMMARY", t.Content)
+	if t.Description != "" {
+		w.prop("DESCRIPTION", t.Description)
+	}
+
+	switch kind {
+	case Event:
+		if !hasEnd {
+			end, hasEnd = defaultEnd(start, eventDuration)
+		}
+		w.line(start.prop("DTSTART"))
+		if hasEnd {
+			w.line(end.prop("DTEND"))
+		}
+	case Todo:
+		// DUE must be later than DTSTART, so a task without a duration is only given a DUE,
+		// unless it recurs, which requires a DTSTART.
+		switch {
+		case hasEnd:
+			w.line(start.prop("DTSTART"))
+			w.line(end.prop("DUE"))
+		case recurring:
+			w.line(start.prop("DTSTART"))
+		default:
+			w.line(start.prop("DUE"))
+		}
+		if t.IsCompleted {
+			w.line("STATUS:COMPLETED")
+		} else {
+			w.line("STATUS:NEEDS-ACTION")
+		}
+	}
+
+	if recurring {
+		w.line("RRULE:" + rule)
+	}
+	if p, ok := priorities[t.Priority]; ok {
+		w.line("PRIORITY:" + p)
+	}
+	if len(t.Labels) > 0 {
+		values := make([]string, len(t.Labels))
+		for i, label := range t.Labels {
+			values[i] = escapeText(label)
+		}
+		w.line("CATEGORIES:" + strings.Join(values, ","))
+	}
+	if t.ParentID != "" {
+		w.line("RELATED-TO:" + UID(t.ParentID))
+	}
+	if t.URL != "" {
+		w.line("URL:" + t.URL)
+	}
+	w.line("END:" + string(kind))
+}
+
+// endOf returns when a task ends according to its duration. Minute durations of all-day tasks are ignored.
+func endOf(t todoist.Task, start moment) (moment, bool) {
+	d := t.Duration
+	if d == nil || d.Amount <= 0 || (start.allDay && d.Unit != todoist.DurationUnitDay) {
+		return moment{}, false
+	}
+	return start.add(*d), true
+}
+
+// defaultEnd returns the end of an event for a task without a duration: the next day for all-day
+// events and eventDuration after the start for timed ones.
+func defaultEnd(start moment, eventDuration time.Duration) (moment, bool) {
+	switch {
+	case start.allDay:
+		return start.add(todoist.TaskDuration{Amount: 1, Unit: todoist.DurationUnitDay}), true
+	case eventDuration > 0:
+		return start.add(todoist.TaskDuration{Amount: int(eventDuration / time.Minute), Unit: todoist.DurationUnitMinute}), true
+	}
+	return moment{}, false
+}
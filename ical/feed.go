@@ -0,0 +1,112 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Feed is an http.Handler serving the tasks of a project as a calendar that apps can subscribe to.
+//
+// The project is taken from the "project" path value when the handler is registered with a
+// pattern such as "GET /calendars/{project}", or else from the "project" query parameter.
+// Without a project all tasks are served. The "kind" query parameter selects "event" or "todo"
+// components and overrides Options.Kind.
+//
+// The handler does no authentication of its own and serves with the token of its client, so anyone
+// who can reach it can read the tasks; without a project that is every task of the account. Callers
+// must wrap it in their own authentication, or at least restrict it to a secret path, before
+// exposing it.
+type Feed struct {
+	// OnSkip receives the tasks left out of the feed because their due date cannot be written;
+	// they are dropped silently otherwise.
+	OnSkip func(task todoist.Task, err error)
+
+	client *todoist.TodoistClient
+	opts   Options
+}
+
+// NewFeed creates a feed handler that reads tasks through client.
+func NewFeed(client *todoist.TodoistClient, opts Options) *Feed {
+	return &Feed{client: client, opts: opts}
+}
+
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	opts := f.opts
+	switch r.URL.Query().Get("kind") {
+	case "":
+	case "event":
+		opts.Kind = Event
+	case "todo":
+		opts.Kind = Todo
+	default:
+		http.Error(w, "kind must be event or todo", http.StatusBadRequest)
+		return
+	}
+
+	projectID := r.PathValue("project")
+	if projectID == "" {
+		projectID = r.URL.Query().Get("project")
+	}
+
+	if projectID != "" && opts.Name == "" {
+		project, err := f.client.GetProject(projectID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		opts.Name = project.Name
+	}
+
+	tasks, err := f.client.GetTasks(projectID, "", "")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	tasks = f.skipInvalid(tasks, opts)
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, tasks, opts); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if r.Method == http.MethodGet {
+		w.Write(buf.Bytes())
+	}
+}
+
+// skipInvalid removes the tasks whose due date cannot be written, so that one malformed task
+// does not take down the whole feed.
+func (f *Feed) skipInvalid(tasks []todoist.Task, opts Options) []todoist.Task {
+	valid := tasks[:0]
+	for _, t := range tasks {
+		if t.Due != nil {
+			if _, err := parseDue(t.Due, opts.UTC); err != nil {
+				if f.OnSkip != nil {
+					f.OnSkip(t, err)
+				}
+				continue
+			}
+		}
+		valid = append(valid, t)
+	}
+	return valid
+}
+
+// writeError reports a failed Todoist request, passing on not found errors.
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *todoist.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		http.Error(w, "project not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, "failed to load tasks", http.StatusBadGateway)
+}
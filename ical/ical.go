@@ -0,0 +1,56 @@
+// Package ical converts Todoist tasks to and from iCalendar (RFC 5545) data, so due dates
+// show up in calendar apps.
+package ical
+
+import (
+	"strings"
+	"time"
+)
+
+// Kind is the type of calendar component written for each task.
+type Kind string
+
+const (
+	// Event writes tasks as VEVENT components, which every calendar app shows.
+	Event Kind = "VEVENT"
+	// Todo writes tasks as VTODO components, which some apps show as reminders or a task list.
+	Todo Kind = "VTODO"
+)
+
+// DefaultProductID identifies this package in the PRODID property of exported calendars.
+const DefaultProductID = "-//todoist-go//ical//EN"
+
+// uidDomain is appended to task IDs to form globally unique UIDs.
+const uidDomain = "@todoist.com"
+
+// Options configures an export.
+type Options struct {
+	// Kind is the component written for each task. Defaults to Event.
+	Kind Kind
+	// Name is shown by calendar apps as the calendar name.
+	Name string
+	// ProductID is written as the PRODID property. Defaults to DefaultProductID.
+	ProductID string
+	// UTC writes timed dues in UTC instead of the task's timezone.
+	UTC bool
+	// EventDuration is the length of timed events for tasks without a duration.
+	// Zero writes them as events without an end.
+	EventDuration time.Duration
+	// Now returns the time written as DTSTAMP. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// UID returns the stable iCalendar UID of a task.
+func UID(taskID string) string {
+	return "task-" + taskID + uidDomain
+}
+
+// TaskID returns the task ID a UID was derived from, and whether uid was created by UID.
+func TaskID(uid string) (string, bool) {
+	id, ok := strings.CutPrefix(uid, "task-")
+	if !ok {
+		return "", false
+	}
+	id, ok = strings.CutSuffix(id, uidDomain)
+	return id, ok && id != ""
+}
@@ -0,0 +1,240 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// UIDStore is where an import records the UID an entry was created from.
+type UIDStore int
+
+const (
+	// UIDInDescription appends the UID to the task description.
+	UIDInDescription UIDStore = iota
+	// UIDInComment adds the UID as a comment on the task, keeping the description as in the calendar.
+	UIDInComment
+)
+
+// uidMarker starts the line that records an imported UID.
+const uidMarker = "ical-uid: "
+
+// DefaultCompletedWindow is how far back an import looks for completed tasks by default.
+const DefaultCompletedWindow = 365 * 24 * time.Hour
+
+// ImportOptions configures an import.
+type ImportOptions struct {
+	// ProjectID is the project tasks are created in. Defaults to the Inbox.
+	ProjectID string
+	// SectionID is the section tasks are created in.
+	SectionID string
+	// Labels are added to every task, in addition to the entry's categories.
+	Labels []string
+	// UIDStore is where UIDs are recorded to recognize entries on later imports.
+	UIDStore UIDStore
+	// CompletedWindow is how far back completed tasks are searched for recorded UIDs, so that an
+	// import does not page through the whole history of a long-lived project. Entries whose task
+	// was completed before the window are imported again. Defaults to DefaultCompletedWindow.
+	CompletedWindow time.Duration
+	// Location is used for floating times and unknown timezones. Defaults to time.Local.
+	Location *time.Location
+}
+
+// ImportResult reports what an import did.
+type ImportResult struct {
+	// Created are the tasks created, in calendar order.
+	Created []todoist.Task
+	// Skipped are the UIDs of entries that were already imported.
+	Skipped []string
+	// Ignored are the UIDs of entries without a summary, and of completed or cancelled entries.
+	Ignored []string
+	// Invalid are the entries that could not be read.
+	Invalid []EntryError
+}
+
+// Import creates a task for every VEVENT and VTODO of an iCalendar object.
+//
+// Each task gets the entry's summary, description, start as due date or time, length as duration,
+// and categories as labels. Recurring entries are imported as their first occurrence. The UID of
+// every entry is recorded in the task, so entries whose UID is found on an active task of the
+// project, or one completed within opts.CompletedWindow, are skipped and importing the same
+// calendar again creates no duplicates.
+// Entries exported by this package from tasks that still exist are skipped as well.
+//
+// Entries that cannot be read are reported in the result and do not stop the import, but a failed
+// request does. The result returned with its error lists the tasks created before it, whose UIDs
+// are recorded, so importing the calendar again continues where it stopped.
+func Import(client *todoist.TodoistClient, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	entries, err := Decode(r, loc)
+	var invalid DecodeErrors
+	if err != nil && !errors.As(err, &invalid) {
+		return nil, err
+	}
+
+	known, err := importedUIDs(client, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Invalid: invalid}
+	for _, e := range entries {
+		switch {
+		case known[e.UID]:
+			result.Skipped = append(result.Skipped, e.UID)
+			continue
+		case strings.TrimSpace(e.Summary) == "", e.Status == "COMPLETED", e.Status == "CANCELLED":
+			result.Ignored = append(result.Ignored, e.UID)
+			continue
+		}
+
+		params := taskParams(e, opts)
+		if e.UID != "" && opts.UIDStore == UIDInDescription {
+			params.Description = appendUID(params.Description, e.UID)
+		}
+		task, err := client.CreateTask(params)
+		if err != nil {
+			return result, fmt.Errorf("ical: import %q: %w", e.Summary, err)
+		}
+		if e.UID != "" && opts.UIDStore == UIDInComment {
+			if _, err := client.CreateComment(todoist.CommentParams{TaskID: task.ID, Content: uidMarker + e.UID}); err != nil {
+				return result, fmt.Errorf("ical: record UID of %q: %w", e.Summary, err)
+			}
+		}
+
+		result.Created = append(result.Created, *task)
+		if e.UID != "" {
+			known[e.UID] = true
+		}
+	}
+	return result, nil
+}
+
+// importedUIDs returns the UIDs recorded on the active and recently completed tasks of the
+// import's project, and the UIDs this package exports for them.
+func importedUIDs(client *todoist.TodoistClient, opts ImportOptions) (map[string]bool, error) {
+	tasks, err := client.GetTasks(opts.ProjectID, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("ical: list tasks: %w", err)
+	}
+	window := opts.CompletedWindow
+	if window <= 0 {
+		window = DefaultCompletedWindow
+	}
+	completed, err := client.GetAllCompletedTasks(todoist.CompletedTasksParams{
+		ProjectID: opts.ProjectID,
+		Since:     time.Now().Add(-window),
+		WithItems: true,
+		WithNotes: opts.UIDStore == UIDInComment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ical: list completed tasks: %w", err)
+	}
+
+	known := make(map[string]bool)
+	record := func(text string) {
+		for _, uid := range recordedUIDs(text) {
+			known[uid] = true
+		}
+	}
+	for _, t := range tasks {
+		known[UID(t.ID)] = true
+		record(t.Description)
+		if opts.UIDStore != UIDInComment || t.CommentCount == 0 {
+			continue
+		}
+
+		comments, err := client.GetComments(t.ID, "")
+		if err != nil {
+			return nil, fmt.Errorf("ical: list comments: %w", err)
+		}
+		for _, c := range comments {
+			record(c.Content)
+		}
+	}
+	for _, c := range completed {
+		known[UID(c.TaskID)] = true
+		if c.Item != nil {
+			record(c.Item.Description)
+		}
+		for _, n := range c.Notes {
+			record(n.Content)
+		}
+	}
+	return known, nil
+}
+
+// recordedUIDs returns the UIDs recorded in a description or comment.
+func recordedUIDs(text string) []string {
+	var uids []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		if uid, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), uidMarker); ok && uid != "" {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
+
+func appendUID(description, uid string) string {
+	if description == "" {
+		return uidMarker + uid
+	}
+	return description + "\n\n" + uidMarker + uid
+}
+
+// taskParams maps an entry to the parameters of a new task.
+func taskParams(e Entry, opts ImportOptions) todoist.TaskParams {
+	params := todoist.TaskParams{
+		Content:     e.Summary,
+		Description: e.Description,
+		ProjectID:   opts.ProjectID,
+		SectionID:   opts.SectionID,
+		Priority:    priority(e.Priority),
+	}
+
+	seen := make(map[string]bool)
+	for _, label := range append(append([]string(nil), opts.Labels...), e.Categories...) {
+		if !seen[label] {
+			seen[label] = true
+			params.Labels = append(params.Labels, label)
+		}
+	}
+
+	switch {
+	case e.Start.IsZero():
+	case e.AllDay:
+		params.DueDate = e.Start.Format(time.DateOnly)
+		// An all-day entry ends at the start of the following day, so only longer ones get a duration.
+		if days := int(e.End.Sub(e.Start).Round(24*time.Hour) / (24 * time.Hour)); !e.End.IsZero() && days > 1 {
+			params = params.WithDurationDays(days)
+		}
+	default:
+		params.DueDatetime = e.Start.UTC().Format(time.RFC3339)
+		if d := e.End.Sub(e.Start); !e.End.IsZero() && d > 0 {
+			params = params.WithDuration(d)
+		}
+	}
+	return params
+}
+
+// priority maps an iCalendar priority to Todoist, the inverse of the export mapping.
+func priority(p int) todoist.Priority {
+	switch {
+	case p == 1:
+		return todoist.PriorityP1
+	case p >= 2 && p <= 4:
+		return todoist.PriorityP2
+	case p == 5:
+		return todoist.PriorityP3
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package ical
+
+import (
+	"strconv"
+	"strings"
+)
+
+var frequencies = map[string]string{
+	"hour": "HOURLY", "hours": "HOURLY",
+	"day": "DAILY", "days": "DAILY",
+	"week": "WEEKLY", "weeks": "WEEKLY",
+	"month": "MONTHLY", "months": "MONTHLY",
+	"year": "YEARLY", "years": "YEARLY",
+}
+
+var weekdays = map[string]string{
+	"mon": "MO", "monday": "MO", "mondays": "MO",
+	"tue": "TU", "tues": "TU", "tuesday": "TU", "tuesdays": "TU",
+	"wed": "WE", "wednesday": "WE", "wednesdays": "WE",
+	"thu": "TH", "thur": "TH", "thurs": "TH", "thursday": "TH", "thursdays": "TH",
+	"fri": "FR", "friday": "FR", "fridays": "FR",
+	"sat": "SA", "saturday": "SA", "saturdays": "SA",
+	"sun": "SU", "sunday": "SU", "sundays": "SU",
+}
+
+var ordinals = map[string]string{
+	"first": "1", "1st": "1",
+	"second": "2", "2nd": "2",
+	"third": "3", "3rd": "3",
+	"fourth": "4", "4th": "4",
+	"last": "-1",
+}
+
+// shorthands are recurrences Todoist accepts without "every".
+var shorthands = map[string]string{
+	"daily":    "every day",
+	"weekly":   "every week",
+	"monthly":  "every month",
+	"yearly":   "every year",
+	"annually": "every year",
+}
+
+// RecurrenceRule translates the English recurrence of a due string, such as "every other week",
+// "every mon, fri at 9am" or "every last day", to the value of an RRULE property.
+// It reports false for recurrences an RRULE cannot express, such as "every!" which repeats
+// from the completion date, and for phrasings it does not know.
+func RecurrenceRule(dueString string) (string, bool) {
+	s := strings.ToLower(strings.TrimSpace(dueString))
+	// The time of day and start date are part of DTSTART.
+	for _, sep := range []string{" at ", " starting ", " from "} {
+		if i := strings.Index(s, sep); i >= 0 {
+			s = s[:i]
+		}
+	}
+	if long, ok := shorthands[s]; ok {
+		s = long
+	}
+
+	rest, ok := strings.CutPrefix(s, "every ")
+	if !ok {
+		return "", false
+	}
+
+	var fields []string
+	for _, f := range strings.FieldsFunc(rest, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if f != "and" {
+			fields = append(fields, f)
+		}
+	}
+
+	interval := 1
+	if len(fields) > 0 {
+		if fields[0] == "other" {
+			interval, fields = 2, fields[1:]
+		} else if n, err := strconv.Atoi(fields[0]); err == nil && n > 0 {
+			interval, fields = n, fields[1:]
+		}
+	}
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	rule, ok := ruleFor(fields)
+	if !ok {
+		return "", false
+	}
+	if interval > 1 {
+		rule += ";INTERVAL=" + strconv.Itoa(interval)
+	}
+	return rule, true
+}
+
+func ruleFor(fields []string) (string, bool) {
+	if len(fields) == 1 {
+		switch f := fields[0]; f {
+		case "weekday", "weekdays", "workday", "workdays":
+			return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", true
+		case "weekend", "weekends":
+			return "FREQ=WEEKLY;BYDAY=SA,SU", true
+		default:
+			if freq, ok := frequencies[f]; ok {
+				return "FREQ=" + freq, true
+			}
+		}
+	}
+
+	if days, ok := mapAll(fields, func(f string) (string, bool) {
+		day, ok := weekdays[f]
+		return day, ok
+	}); ok {
+		return "FREQ=WEEKLY;BYDAY=" + strings.Join(days, ","), true
+	}
+
+	if len(fields) == 2 {
+		if n, ok := ordinals[fields[0]]; ok {
+			if fields[1] == "day" {
+				return "FREQ=MONTHLY;BYMONTHDAY=" + n, true
+			}
+			if day, ok := weekdays[fields[1]]; ok {
+				return "FREQ=MONTHLY;BYDAY=" + n + day, true
+			}
+		}
+	}
+
+	if days, ok := mapAll(fields, monthDay); ok {
+		return "FREQ=MONTHLY;BYMONTHDAY=" + strings.Join(days, ","), true
+	}
+	return "", false
+}
+
+// monthDay parses an ordinal day of the month such as "15th".
+func monthDay(f string) (string, bool) {
+	if len(f) < 3 {
+		return "", false
+	}
+	switch f[len(f)-2:] {
+	case "st", "nd", "rd", "th":
+	default:
+		return "", false
+	}
+	n, err := strconv.Atoi(f[:len(f)-2])
+	if err != nil || n < 1 || n > 31 {
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+// mapAll applies fn to every field and reports whether it succeeded for all of them.
+func mapAll(fields []string, fn func(string) (string, bool)) ([]string, bool) {
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		v, ok := fn(f)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, v)
+	}
+	return out, true
+}
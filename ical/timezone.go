@@ -0,0 +1,114 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// zoneRange collects the timezones used in a calendar and the years they are used in.
+type zoneRange struct {
+	locs  map[string]*time.Location
+	first map[string]int
+	last  map[string]int
+}
+
+func newZoneRange() *zoneRange {
+	return &zoneRange{locs: make(map[string]*time.Location), first: make(map[string]int), last: make(map[string]int)}
+}
+
+func (z *zoneRange) add(m moment) {
+	if m.loc == nil {
+		return
+	}
+	name, year := m.loc.String(), m.t.Year()
+	if _, ok := z.locs[name]; !ok {
+		z.locs[name], z.first[name], z.last[name] = m.loc, year, year
+		return
+	}
+	z.first[name] = min(z.first[name], year)
+	z.last[name] = max(z.last[name], year)
+}
+
+// write writes a VTIMEZONE component for every collected timezone. Each one lists the offset
+// transitions from the start of the first year a time is used in until the end of the year
+// after the last, so recurring tasks keep the right offsets for a while.
+func (z *zoneRange) write(w *writer) {
+	names := make([]string, 0, len(z.locs))
+	for name := range z.locs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		loc := z.locs[name]
+		from := time.Date(z.first[name], time.January, 1, 0, 0, 0, 0, loc)
+		to := time.Date(z.last[name]+2, time.January, 1, 0, 0, 0, 0, loc)
+		writeTimezone(w, loc, from, to)
+	}
+}
+
+func writeTimezone(w *writer, loc *time.Location, from, to time.Time) {
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + paramValue(loc.String()))
+
+	// The first observance covers the start of the range.
+	_, offset := from.Zone()
+	writeObservance(w, from, offset, offset)
+
+	for t := from; t.Before(to); {
+		next := t.AddDate(0, 0, 1)
+		_, before := t.Zone()
+		if _, after := next.Zone(); after != before {
+			at := transition(t, next)
+			writeObservance(w, at, before, after)
+		}
+		t = next
+	}
+
+	w.line("END:VTIMEZONE")
+}
+
+// transition finds the instant in (lo, hi] at which the UTC offset changes, to the second.
+func transition(lo, hi time.Time) time.Time {
+	_, offset := lo.Zone()
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, o := mid.Zone(); o == offset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// writeObservance writes a STANDARD or DAYLIGHT sub-component starting at the instant at.
+// Its DTSTART is the local time before the change, as RFC 5545 requires.
+func writeObservance(w *writer, at time.Time, from, to int) {
+	kind := "STANDARD"
+	if at.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, _ := at.Zone()
+
+	w.line("BEGIN:" + kind)
+	w.line("DTSTART:" + at.UTC().Add(time.Duration(from)*time.Second).Format(dateTimeFormat))
+	w.line("TZOFFSETFROM:" + formatOffset(from))
+	w.line("TZOFFSETTO:" + formatOffset(to))
+	w.prop("TZNAME", name)
+	w.line("END:" + kind)
+}
+
+// formatOffset formats a UTC offset in seconds as +hhmm, or +hhmmss when it has seconds.
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, h, m)
+}
@@ -0,0 +1,84 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the longest content line in octets, excluding the line break, before it is folded.
+const maxLineLength = 75
+
+// writer writes content lines, folding long lines and remembering the first error.
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newWriter(w io.Writer) *writer {
+	return &writer{w: bufio.NewWriter(w)}
+}
+
+// line writes one content line, folding it into continuation lines of at most maxLineLength octets.
+func (w *writer) line(s string) {
+	if w.err != nil {
+		return
+	}
+
+	limit := maxLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.write(s[:cut])
+		w.write("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = maxLineLength - 1
+	}
+	w.write(s)
+	w.write("\r\n")
+}
+
+// prop writes a property with a text value.
+func (w *writer) prop(name, value string) {
+	w.line(name + ":" + escapeText(value))
+}
+
+func (w *writer) write(s string) {
+	if w.err == nil {
+		_, w.err = w.w.WriteString(s)
+	}
+}
+
+func (w *writer) flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes a TEXT value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// paramValue quotes a parameter value if it contains characters that are not allowed unquoted.
+func paramValue(s string) string {
+	s = strings.ReplaceAll(s, `"`, "'")
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + s + `"`
+	}
+	return s
+}
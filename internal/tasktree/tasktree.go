@@ -0,0 +1,57 @@
+// Package tasktree creates nested tasks, for the packages that turn documents and templates
+// into tasks.
+package tasktree
+
+import (
+	"fmt"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Tree describes how the nodes of a document become tasks of a project.
+type Tree[N any] struct {
+	Client    *todoist.TodoistClient
+	ProjectID string
+	// Params returns the parameters of the task of a node. Create sets its project, section and parent.
+	Params func(N) todoist.TaskParams
+	// Children returns the subtasks of a node.
+	Children func(N) []N
+	// Name identifies a node in errors, for example "markdown: line 3".
+	Name func(N) string
+	// Done, if set, is called once the task of a node and those of its subtasks exist, for example
+	// to complete it. Its error is returned as is.
+	Done func(N, *todoist.Task) error
+	// Created receives the created tasks, each before its subtasks.
+	Created *[]todoist.Task
+}
+
+// Create creates the tasks of nodes in a section, or outside of sections when sectionID is empty,
+// and below parentID if it is set. Each task is followed by its subtasks, so parents exist before
+// their children are created.
+//
+// Create stops at the first request that fails. Every task created before it has been appended to
+// Created by then, so callers can hand out the partial result together with the error.
+func (t *Tree[N]) Create(nodes []N, sectionID, parentID string) error {
+	for _, n := range nodes {
+		params := t.Params(n)
+		params.ProjectID = t.ProjectID
+		params.SectionID = sectionID
+		params.ParentID = parentID
+
+		task, err := t.Client.CreateTask(params)
+		if err != nil {
+			return fmt.Errorf("%s: create task: %w", t.Name(n), err)
+		}
+		*t.Created = append(*t.Created, *task)
+
+		if err := t.Create(t.Children(n), sectionID, task.ID); err != nil {
+			return err
+		}
+		if t.Done != nil {
+			if err := t.Done(n, task); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default limits of a ClientManager, following the documented Todoist limit of 450 requests per 15 minutes.
+const (
+	DefaultRateLimit          = 450
+	DefaultRateLimitWindow    = 15 * time.Minute
+	DefaultUnhealthyThreshold = 5
+)
+
+// ErrAccountUnhealthy is returned for requests of an account that failed too often in a row.
+var ErrAccountUnhealthy = errors.New("account is unhealthy")
+
+// AccountTokenFunc looks up the token source of an account.
+type AccountTokenFunc func(accountID string) (TokenSource, error)
+
+// ManagerOptions configures a ClientManager.
+type ManagerOptions struct {
+	Transport          http.RoundTripper // Shared by all clients, defaults to http.DefaultTransport
+	RateLimit          int               // Requests allowed per account and window, defaults to DefaultRateLimit
+	RateLimitWindow    time.Duration     // Defaults to DefaultRateLimitWindow
+	UnhealthyThreshold int               // Consecutive failures after which an account is unhealthy
+	UnhealthyCooldown  time.Duration     // How long an unhealthy account is rejected before it is tried again
+	IdleTimeout        time.Duration     // Clients unused for this long are evicted by EvictIdle
+}
+
+// AccountMetrics are the request statistics of an account.
+type AccountMetrics struct {
+	AccountID           string
+	Requests            int64
+	Failures            int64
+	RateLimited         int64
+	ConsecutiveFailures int
+	Healthy             bool
+	TotalLatency        time.Duration
+	LastUsed            time.Time
+	RateLimitedUntil    time.Time
+}
+
+// AverageLatency returns the mean duration of the account's requests.
+func (m AccountMetrics) AverageLatency() time.Duration {
+	if m.Requests == 0 {
+		return 0
+	}
+	return m.TotalLatency / time.Duration(m.Requests)
+}
+
+// ClientManager hands out clients for many accounts that share one transport,
+// while keeping rate limits and health state per account.
+type ClientManager struct {
+	tokens AccountTokenFunc
+	opts   ManagerOptions
+
+	mu       sync.Mutex
+	accounts map[string]*account
+	// limiters outlive evicted and removed clients until they are full again, so that
+	// recreating a client does not reset the rate limit of its account.
+	limiters map[string]*rateLimiter
+}
+
+// account holds the client and state of one account.
+type account struct {
+	id      string
+	client  *TodoistClient
+	limiter *rateLimiter
+
+	mu             sync.Mutex
+	metrics        AccountMetrics
+	unhealthyUntil time.Time
+}
+
+// NewClientManager creates a manager that looks up account tokens with tokens.
+func NewClientManager(tokens AccountTokenFunc, opts ManagerOptions) *ClientManager {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	if opts.RateLimit <= 0 {
+		opts.RateLimit = DefaultRateLimit
+	}
+	if opts.RateLimitWindow <= 0 {
+		opts.RateLimitWindow = DefaultRateLimitWindow
+	}
+	if opts.UnhealthyThreshold <= 0 {
+		opts.UnhealthyThreshold = DefaultUnhealthyThreshold
+	}
+	if opts.UnhealthyCooldown <= 0 {
+		opts.UnhealthyCooldown = time.Minute
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = 30 * time.Minute
+	}
+
+	return &ClientManager{
+		tokens:   tokens,
+		opts:     opts,
+		accounts: make(map[string]*account),
+		limiters: make(map[string]*rateLimiter),
+	}
+}
+
+// Client returns the client of an account, creating it on first use.
+func (m *ClientManager) Client(accountID string) (*TodoistClient, error) {
+	if client, ok := m.existing(accountID); ok {
+		return client, nil
+	}
+
+	// The token is looked up without holding the lock, as it may take a database query or a
+	// token refresh that would otherwise hold up the clients of all other accounts.
+	ts, err := m.tokens(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Another call may have created the client in the meantime.
+	if acc, ok := m.accounts[accountID]; ok {
+		acc.touch()
+		return acc.client, nil
+	}
+
+	limiter, ok := m.limiters[accountID]
+	if !ok {
+		limiter = newRateLimiter(m.opts.RateLimit, m.opts.RateLimitWindow)
+		m.limiters[accountID] = limiter
+	}
+	acc := &account{
+		id:      accountID,
+		limiter: limiter,
+		metrics: AccountMetrics{AccountID: accountID, Healthy: true, LastUsed: time.Now()},
+	}
+	acc.client = NewTodoistClientWithTokenSource(ts)
+	acc.client.HTTPClient = &http.Client{
+		Transport: &accountTransport{base: m.opts.Transport, account: acc, opts: &m.opts},
+	}
+
+	m.accounts[accountID] = acc
+	return acc.client, nil
+}
+
+// existing returns the client of an account if it has one.
+func (m *ClientManager) existing(accountID string) (*TodoistClient, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	acc, ok := m.accounts[accountID]
+	if !ok {
+		return nil, false
+	}
+	acc.touch()
+	return acc.client, true
+}
+
+// Remove drops the client of an account, e.g. after its token was revoked. The rate limit of the
+// account is kept until EvictIdle finds it refilled.
+func (m *ClientManager) Remove(accountID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.accounts, accountID)
+}
+
+// EvictIdle removes clients that have not been used within the idle timeout and returns their account IDs.
+func (m *ClientManager) EvictIdle() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var evicted []string
+	cutoff := time.Now().Add(-m.opts.IdleTimeout)
+	for id, acc := range m.accounts {
+		if acc.lastUsed().Before(cutoff) {
+			delete(m.accounts, id)
+			evicted = append(evicted, id)
+		}
+	}
+	for id, limiter := range m.limiters {
+		if _, ok := m.accounts[id]; !ok && limiter.full() {
+			delete(m.limiters, id)
+		}
+	}
+	return evicted
+}
+
+// Run evicts idle clients periodically until the context is cancelled.
+func (m *ClientManager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.opts.IdleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.EvictIdle()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Metrics returns the metrics of an account and whether it has a client.
+func (m *ClientManager) Metrics(accountID string) (AccountMetrics, bool) {
+	m.mu.Lock()
+	acc, ok := m.accounts[accountID]
+	m.mu.Unlock()
+
+	if !ok {
+		return AccountMetrics{}, false
+	}
+	return acc.snapshot(), true
+}
+
+// AllMetrics returns the metrics of all accounts with a client.
+func (m *ClientManager) AllMetrics() []AccountMetrics {
+	m.mu.Lock()
+	accounts := make([]*account, 0, len(m.accounts))
+	for _, acc := range m.accounts {
+		accounts = append(accounts, acc)
+	}
+	m.mu.Unlock()
+
+	metrics := make([]AccountMetrics, len(accounts))
+	for i, acc := range accounts {
+		metrics[i] = acc.snapshot()
+	}
+	return metrics
+}
+
+func (a *account) touch() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.metrics.LastUsed = time.Now()
+}
+
+func (a *account) lastUsed() time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.metrics.LastUsed
+}
+
+func (a *account) snapshot() AccountMetrics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	metrics := a.metrics
+	metrics.Healthy = time.Now().After(a.unhealthyUntil)
+	metrics.RateLimitedUntil = a.limiter.blockedUntil()
+	return metrics
+}
+
+// accountTransport applies the rate limit and health checks of an account and records its metrics.
+type accountTransport struct {
+	base    http.RoundTripper
+	account *account
+	opts    *ManagerOptions
+}
+
+func (t *accountTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	acc := t.account
+
+	acc.mu.Lock()
+	unhealthy := time.Now().Before(acc.unhealthyUntil)
+	acc.mu.Unlock()
+	if unhealthy {
+		closeRequestBody(req)
+		return nil, ErrAccountUnhealthy
+	}
+
+	if err := acc.limiter.wait(req.Context()); err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	acc.metrics.Requests++
+	acc.metrics.TotalLatency += latency
+	acc.metrics.LastUsed = time.Now()
+
+	failed := err != nil || resp.StatusCode >= 500 || resp.StatusCode == http.StatusUnauthorized
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		acc.metrics.RateLimited++
+		acc.limiter.block(retryAfter(resp.Header.Get("Retry-After")))
+	}
+
+	if failed {
+		acc.metrics.Failures++
+		acc.metrics.ConsecutiveFailures++
+		if acc.metrics.ConsecutiveFailures >= t.opts.UnhealthyThreshold {
+			acc.unhealthyUntil = time.Now().Add(t.opts.UnhealthyCooldown)
+			acc.metrics.ConsecutiveFailures = 0
+		}
+	} else {
+		acc.metrics.ConsecutiveFailures = 0
+	}
+
+	return resp, err
+}
+
+// closeRequestBody closes the body of a request that is not sent, as required of a RoundTripper.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// retryAfter parses a Retry-After header given in seconds, defaulting to one minute.
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Minute
+}
+
+// rateLimiter is a token bucket that refills limit tokens evenly over window.
+type rateLimiter struct {
+	mu       sync.Mutex
+	limit    float64
+	rate     float64 // tokens per second
+	tokens   float64
+	last     time.Time
+	blockEnd time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  float64(limit),
+		rate:   float64(limit) / window.Seconds(),
+		tokens: float64(limit),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a request may be sent or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long to wait.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.blockEnd) {
+		return l.blockEnd.Sub(now)
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.limit {
+		l.tokens = l.limit
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// block stops all requests for d, after the API reported that the limit was exceeded.
+func (l *rateLimiter) block(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.blockEnd = time.Now().Add(d)
+	l.tokens = 0
+}
+
+// full reports whether the bucket has refilled and is not blocked, so that the limiter behaves
+// like a new one.
+func (l *rateLimiter) full() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	return !now.Before(l.blockEnd) && l.tokens+now.Sub(l.last).Seconds()*l.rate >= l.limit
+}
+
+func (l *rateLimiter) blockedUntil() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.blockEnd
+}
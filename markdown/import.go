@@ -0,0 +1,170 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/internal/tasktree"
+)
+
+// ImportOptions configures an import.
+type ImportOptions struct {
+	// ProjectID is the existing project to import into. Sections that already exist in it,
+	// by name, are reused.
+	ProjectID string
+	// ProjectName is the name of the project created when ProjectID is empty.
+	// Defaults to the document title.
+	ProjectName string
+}
+
+// ImportResult lists what an import created.
+type ImportResult struct {
+	ProjectID string
+	Sections  []todoist.Section
+	Tasks     []todoist.Task
+}
+
+// Import parses a Markdown checklist and creates its sections and tasks in a project, keeping
+// the nesting of subtasks. Checked items are completed after they are created.
+//
+// All items are checked before anything is created; problems are returned as a
+// todoist.ValidationErrors with one entry per line. Import stops at the first failed request, and
+// its result then names the project and the sections and tasks already in it, so a retry can
+// import into that project instead of starting over.
+func Import(client *todoist.TodoistClient, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	doc, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(doc); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{ProjectID: opts.ProjectID}
+	existing := make(map[string]string)
+	if opts.ProjectID == "" {
+		name := opts.ProjectName
+		if name == "" {
+			name = doc.Title
+		}
+		if strings.TrimSpace(name) == "" {
+			return nil, todoist.ValidationErrors{{Field: "project", Message: "a project ID, name or document title is required"}}
+		}
+		project, err := client.CreateProject(todoist.ProjectParams{Name: name})
+		if err != nil {
+			return nil, fmt.Errorf("markdown: create project: %w", err)
+		}
+		result.ProjectID = project.ID
+	} else {
+		sections, err := client.GetSections(opts.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("markdown: list sections: %w", err)
+		}
+		for _, s := range sections {
+			existing[s.Name] = s.ID
+		}
+	}
+
+	tree := &tasktree.Tree[*Item]{
+		Client:    client,
+		ProjectID: result.ProjectID,
+		Params: func(item *Item) todoist.TaskParams {
+			params, _ := item.TaskParams()
+			return params
+		},
+		Children: func(item *Item) []*Item { return item.Children },
+		Name:     func(item *Item) string { return fmt.Sprintf("markdown: line %d", item.Line) },
+		// Checked items are completed after their subtasks, which Todoist would complete with them.
+		Done: func(item *Item, task *todoist.Task) error {
+			if !item.Checked {
+				return nil
+			}
+			if _, err := client.CloseTask(task.ID); err != nil {
+				return fmt.Errorf("markdown: line %d: complete task: %w", item.Line, err)
+			}
+			return nil
+		},
+		Created: &result.Tasks,
+	}
+	if err := tree.Create(doc.Items, "", ""); err != nil {
+		return result, err
+	}
+	for _, s := range doc.Sections {
+		sectionID, ok := existing[s.Name]
+		if !ok {
+			section, err := client.CreateSection(todoist.SectionParams{ProjectID: result.ProjectID, Name: s.Name})
+			if err != nil {
+				return result, fmt.Errorf("markdown: line %d: create section: %w", s.Line, err)
+			}
+			result.Sections = append(result.Sections, *section)
+			sectionID = section.ID
+			existing[s.Name] = sectionID
+		}
+		if err := tree.Create(s.Items, sectionID, ""); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// validate checks every item of a document.
+func validate(doc *Document) error {
+	var errs todoist.ValidationErrors
+	var walk func(items []*Item)
+	walk = func(items []*Item) {
+		for _, item := range items {
+			if _, err := item.TaskParams(); err != nil {
+				errs = append(errs, todoist.ValidationError{Field: fmt.Sprintf("line %d", item.Line), Message: err.Error()})
+			}
+			walk(item.Children)
+		}
+	}
+	walk(doc.Items)
+	for _, s := range doc.Sections {
+		if strings.TrimSpace(s.Name) == "" {
+			errs = append(errs, todoist.ValidationError{Field: fmt.Sprintf("line %d", s.Line), Message: "section name is required"})
+		}
+		walk(s.Items)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// TaskParams returns the parameters creating the item's task, without its location.
+// Dates and RFC 3339 times are sent as such and anything else is left to Todoist's date parser.
+func (item *Item) TaskParams() (todoist.TaskParams, error) {
+	params := todoist.TaskParams{
+		Content:     item.Content,
+		Description: item.Description,
+		Priority:    item.Priority,
+		Labels:      item.Labels,
+	}
+
+	switch due := item.Due; {
+	case due == "":
+	case isLayout(time.DateOnly, due):
+		params.DueDate = due
+	case isLayout(time.RFC3339, due):
+		params.DueDatetime = due
+	case isLayout("2006-01-02T15:04:05", due):
+		t, _ := time.Parse("2006-01-02T15:04:05", due)
+		params.DueString = t.Format("2006-01-02 15:04")
+	default:
+		params.DueString = due
+	}
+
+	if d := item.Duration; d != nil && d.Amount > 0 {
+		params.Duration, params.DurationUnit = d.Amount, d.Unit
+	}
+	return params, params.Validate()
+}
+
+func isLayout(layout, value string) bool {
+	_, err := time.Parse(layout, value)
+	return err == nil
+}
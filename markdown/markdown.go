@@ -0,0 +1,116 @@
+// Package markdown converts Todoist projects to and from Markdown checklists, so project plans
+// can be kept in Git and reviewed like code.
+//
+// A project is written as a document with the project name as the title, the tasks outside
+// sections first and one second-level heading per section:
+//
+//	# Website
+//
+//	- [ ] Renew domain !p3 due:2026-11-01
+//
+//	## Backlog
+//
+//	- [ ] Write landing page copy !p2 @writing due:"every monday" duration:45m
+//	  Keep it short.
+//	  - [ ] Collect quotes
+//
+// Subtasks are nested list items and descriptions are indented lines below their task. The
+// words at the end of a task line hold its priority (!p1 to !p3), labels (@name, or @"two words"
+// for names with spaces), due date (due:) and duration (duration: in minutes "m" or days "d").
+// Words of the content that look like these are escaped with a backslash.
+package markdown
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Document is a project as a Markdown checklist.
+type Document struct {
+	// Title is the text of the first-level heading, normally the project name.
+	Title string
+	// Items are the tasks outside sections.
+	Items    []*Item
+	Sections []*Section
+}
+
+// Section is a second-level heading and the tasks below it.
+type Section struct {
+	Name  string
+	Items []*Item
+	// Line is the line of the heading, zero for sections not read by Parse.
+	Line int
+}
+
+// Item is a task of a checklist.
+type Item struct {
+	Content     string
+	Description string
+	Checked     bool
+	// Priority is zero for the default priority P4.
+	Priority todoist.Priority
+	Labels   []string
+	// Due is a date ("2026-11-01"), a date and time in RFC 3339 ("2026-11-01T08:30:00Z"),
+	// a floating date and time ("2026-11-01T09:30:00") or a recurrence ("every monday").
+	Due      string
+	Duration *todoist.TaskDuration
+	Children []*Item
+	// Line is the line of the item, zero for items not read by Parse.
+	Line int
+}
+
+// Metadata words of a task line.
+const (
+	priorityPrefix = "!p"
+	labelPrefix    = "@"
+	duePrefix      = "due:"
+	durationPrefix = "duration:"
+)
+
+// isMetaLike reports whether a word starts like a metadata word, so it must be escaped in content.
+func isMetaLike(word string) bool {
+	for _, prefix := range []string{priorityPrefix, labelPrefix, duePrefix, durationPrefix} {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// quote returns a metadata value, quoted if it contains spaces or quotes.
+func quote(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\"\\") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// formatDuration writes a duration as "30m" or "2d".
+func formatDuration(d todoist.TaskDuration) string {
+	if d.Unit == todoist.DurationUnitDay {
+		return strconv.Itoa(d.Amount) + "d"
+	}
+	return strconv.Itoa(d.Amount) + "m"
+}
+
+// parseDuration reads a duration written by formatDuration.
+func parseDuration(s string) (todoist.TaskDuration, bool) {
+	if len(s) < 2 {
+		return todoist.TaskDuration{}, false
+	}
+	unit := todoist.DurationUnitMinute
+	switch s[len(s)-1] {
+	case 'm':
+	case 'd':
+		unit = todoist.DurationUnitDay
+	default:
+		return todoist.TaskDuration{}, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return todoist.TaskDuration{}, false
+	}
+	return todoist.TaskDuration{Amount: n, Unit: unit}, true
+}
@@ -0,0 +1,256 @@
+package markdown
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Parse reads a Markdown checklist. The first first-level heading is the title, second-level
+// headings start sections and task list items ("- [ ]" or "- [x]") are tasks, nested by their
+// indentation. Other text is ignored.
+func Parse(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	items := &doc.Items
+
+	type open struct {
+		indent int
+		item   *Item
+	}
+	var stack []open
+	blanks := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for n := 1; scanner.Scan(); n++ {
+		line := expandTabs(strings.TrimRight(scanner.Text(), " \t\r"))
+		if line == "" {
+			blanks++
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		text := line[indent:]
+
+		switch {
+		case indent == 0 && strings.HasPrefix(text, "# "):
+			if doc.Title == "" && len(doc.Items) == 0 && len(doc.Sections) == 0 {
+				doc.Title = strings.TrimSpace(text[2:])
+			}
+			stack = nil
+
+		case indent == 0 && strings.HasPrefix(text, "## "):
+			s := &Section{Name: strings.TrimSpace(text[3:]), Line: n}
+			doc.Sections = append(doc.Sections, s)
+			items, stack = &s.Items, nil
+
+		case isItemLine(text):
+			item, err := parseItem(text)
+			if err != nil {
+				return nil, fmt.Errorf("markdown: line %d: %w", n, err)
+			}
+			item.Line = n
+
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				*items = append(*items, item)
+			} else {
+				parent := stack[len(stack)-1].item
+				parent.Children = append(parent.Children, item)
+			}
+			stack = append(stack, open{indent: indent, item: item})
+
+		default:
+			// Indented text belongs to the innermost task indented less than it.
+			i := len(stack) - 1
+			for i >= 0 && stack[i].indent >= indent {
+				i--
+			}
+			if i < 0 {
+				stack = nil
+				break
+			}
+
+			item := stack[i].item
+			stack = stack[:i+1]
+			text = line[min(indent, stack[i].indent+2):]
+			if strings.HasPrefix(text, `\`) {
+				text = text[1:]
+			}
+			if item.Description != "" {
+				item.Description += strings.Repeat("\n", blanks+1)
+			}
+			item.Description += text
+		}
+		blanks = 0
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("markdown: %w", err)
+	}
+	return doc, nil
+}
+
+// isItemLine reports whether text, without its indentation, is a task list item.
+func isItemLine(text string) bool {
+	if len(text) < 5 || !strings.ContainsRune("-*+", rune(text[0])) || text[1] != ' ' || text[2] != '[' || text[4] != ']' {
+		return false
+	}
+	return strings.ContainsRune(" xX", rune(text[3])) && (len(text) == 5 || text[5] == ' ')
+}
+
+// parseItem reads a task list item: the checkbox, the content and the metadata words at the end.
+func parseItem(text string) (*Item, error) {
+	item := &Item{Checked: text[3] != ' '}
+	line := ""
+	if len(text) > 6 {
+		line = text[6:]
+	}
+
+	words := splitWords(line)
+	end := len(words)
+	for end > 0 {
+		ok, err := item.applyMeta(words[end-1].text)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		end--
+	}
+	reverse(item.Labels)
+
+	content := line
+	if end < len(words) {
+		content = line[:words[end].start]
+	}
+	fields := strings.Split(strings.TrimSpace(content), " ")
+	for i, word := range fields {
+		if strings.HasPrefix(word, `\`) && isMetaLike(strings.TrimLeft(word, `\`)) {
+			fields[i] = word[1:]
+		}
+	}
+	item.Content = strings.Join(fields, " ")
+	if item.Content == "" {
+		return nil, fmt.Errorf("task content is required")
+	}
+	return item, nil
+}
+
+// applyMeta sets the field of a metadata word, reporting false if word is not one. Metadata
+// words are read from the end of the line, so labels are collected in reverse.
+func (item *Item) applyMeta(word string) (bool, error) {
+	switch {
+	case strings.HasPrefix(word, priorityPrefix):
+		p, err := todoist.ParsePriority(word[1:])
+		if err != nil || item.Priority != 0 {
+			return false, nil
+		}
+		item.Priority = p
+
+	case strings.HasPrefix(word, labelPrefix) && len(word) > len(labelPrefix):
+		label, err := unquote(word[len(labelPrefix):])
+		if err != nil {
+			return false, err
+		}
+		item.Labels = append(item.Labels, label)
+
+	case strings.HasPrefix(word, duePrefix):
+		if item.Due != "" {
+			return false, nil
+		}
+		due, err := unquote(word[len(duePrefix):])
+		if err != nil {
+			return false, err
+		}
+		item.Due = due
+
+	case strings.HasPrefix(word, durationPrefix):
+		d, ok := parseDuration(word[len(durationPrefix):])
+		if !ok || item.Duration != nil {
+			return false, nil
+		}
+		item.Duration = &d
+
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func unquote(value string) (string, error) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, nil
+	}
+	s, err := strconv.Unquote(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid quoted value %s", value)
+	}
+	return s, nil
+}
+
+// word is a word of a task line and its offset.
+type word struct {
+	text  string
+	start int
+}
+
+// splitWords splits a task line at spaces, keeping quoted metadata values such as
+// due:"every monday" in one word.
+func splitWords(line string) []word {
+	var words []word
+	for i := 0; i < len(line); {
+		if line[i] == ' ' {
+			i++
+			continue
+		}
+
+		start := i
+		quoted := false
+		for _, prefix := range []string{labelPrefix + `"`, duePrefix + `"`} {
+			if strings.HasPrefix(line[i:], prefix) {
+				i += len(prefix)
+				quoted = true
+				break
+			}
+		}
+		for i < len(line) {
+			c := line[i]
+			if quoted {
+				switch c {
+				case '\\':
+					i++
+				case '"':
+					quoted = false
+				}
+				i++
+				continue
+			}
+			if c == ' ' {
+				break
+			}
+			i++
+		}
+		words = append(words, word{text: line[start:min(i, len(line))], start: start})
+	}
+	return words
+}
+
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	return strings.ReplaceAll(line[:indent], "\t", "    ") + line[indent:]
+}
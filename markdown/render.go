@@ -0,0 +1,170 @@
+package markdown
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Render writes a document as Markdown.
+func Render(w io.Writer, doc *Document) error {
+	bw := bufio.NewWriter(w)
+	if doc.Title != "" {
+		fmt.Fprintf(bw, "# %s\n", doc.Title)
+	}
+	if len(doc.Items) > 0 {
+		if doc.Title != "" {
+			bw.WriteString("\n")
+		}
+		renderItems(bw, doc.Items, "")
+	}
+	for i, s := range doc.Sections {
+		if i > 0 || doc.Title != "" || len(doc.Items) > 0 {
+			bw.WriteString("\n")
+		}
+		fmt.Fprintf(bw, "## %s\n", s.Name)
+		if len(s.Items) > 0 {
+			bw.WriteString("\n")
+			renderItems(bw, s.Items, "")
+		}
+	}
+	return bw.Flush()
+}
+
+func renderItems(w *bufio.Writer, items []*Item, indent string) {
+	for _, item := range items {
+		w.WriteString(indent)
+		if item.Checked {
+			w.WriteString("- [x] ")
+		} else {
+			w.WriteString("- [ ] ")
+		}
+		w.WriteString(itemLine(item))
+		w.WriteString("\n")
+
+		if item.Description != "" {
+			for _, line := range strings.Split(item.Description, "\n") {
+				if line == "" {
+					w.WriteString("\n")
+					continue
+				}
+				if isItemLine(line) || strings.HasPrefix(line, `\`) {
+					line = `\` + line
+				}
+				w.WriteString(indent + "  " + line + "\n")
+			}
+		}
+		renderItems(w, item.Children, indent+"  ")
+	}
+}
+
+// itemLine returns the text of a task line after the checkbox: the escaped content and the metadata words.
+func itemLine(item *Item) string {
+	words := strings.Split(item.Content, " ")
+	for i, word := range words {
+		if isMetaLike(strings.TrimLeft(word, `\`)) {
+			words[i] = `\` + word
+		}
+	}
+	line := strings.Join(words, " ")
+
+	if item.Priority.IsValid() && item.Priority != todoist.PriorityP4 {
+		line += " !" + strings.ToLower(item.Priority.String())
+	}
+	for _, label := range item.Labels {
+		line += " " + labelPrefix + quote(label)
+	}
+	if item.Due != "" {
+		line += " " + duePrefix + quote(item.Due)
+	}
+	if item.Duration != nil && item.Duration.Amount > 0 {
+		line += " " + durationPrefix + formatDuration(*item.Duration)
+	}
+	return line
+}
+
+// FromProject builds the document of a project from its sections and tasks. Tasks are
+// ordered as in Todoist, and subtasks whose parent is missing are treated as top-level tasks.
+func FromProject(project todoist.Project, sections []todoist.Section, tasks []todoist.Task) *Document {
+	doc := &Document{Title: project.Name}
+
+	ids := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		ids[t.ID] = true
+	}
+	children := make(map[string][]todoist.Task)
+	for _, t := range tasks {
+		parent := "section:" + t.SectionID
+		if t.ParentID != "" && ids[t.ParentID] {
+			parent = t.ParentID
+		}
+		children[parent] = append(children[parent], t)
+	}
+	for _, list := range children {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+	}
+
+	var build func(parent string) []*Item
+	build = func(parent string) []*Item {
+		var items []*Item
+		for _, t := range children[parent] {
+			item := itemFromTask(t)
+			item.Children = build(t.ID)
+			items = append(items, item)
+		}
+		return items
+	}
+
+	doc.Items = build("section:")
+	sorted := append([]todoist.Section(nil), sections...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+	for _, s := range sorted {
+		doc.Sections = append(doc.Sections, &Section{Name: s.Name, Items: build("section:" + s.ID)})
+	}
+	return doc
+}
+
+func itemFromTask(t todoist.Task) *Item {
+	item := &Item{
+		Content:     t.Content,
+		Description: t.Description,
+		Checked:     t.IsCompleted,
+		Labels:      t.Labels,
+		Duration:    t.Duration,
+	}
+	if t.Priority != todoist.PriorityP4 {
+		item.Priority = t.Priority
+	}
+	if t.Due != nil {
+		switch {
+		case t.Due.IsRecurring:
+			item.Due = t.Due.String
+		case t.Due.Datetime != "":
+			item.Due = t.Due.Datetime
+		default:
+			item.Due = t.Due.Date
+		}
+	}
+	return item
+}
+
+// Export writes a project's sections and active tasks as Markdown.
+func Export(client *todoist.TodoistClient, projectID string, w io.Writer) error {
+	project, err := client.GetProject(projectID)
+	if err != nil {
+		return fmt.Errorf("markdown: get project: %w", err)
+	}
+	sections, err := client.GetSections(projectID)
+	if err != nil {
+		return fmt.Errorf("markdown: list sections: %w", err)
+	}
+	tasks, err := client.GetTasks(projectID, "", "")
+	if err != nil {
+		return fmt.Errorf("markdown: list tasks: %w", err)
+	}
+	return Render(w, FromProject(*project, sections, tasks))
+}
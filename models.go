@@ -14,7 +14,7 @@ type Project struct {
 	Name           string    `json:"name"`
 	CommentCount   int       `json:"comment_count"`
 	Order          int       `json:"order"`
-	Color          string    `json:"color"`
+	Color          Color     `json:"color"`
 	IsShared       bool      `json:"is_shared"`
 	IsFavorite     bool      `json:"is_favorite"`
 	IsInboxProject bool      `json:"is_inbox_project"`
@@ -28,7 +28,7 @@ type Project struct {
 type ProjectParams struct {
 	Name       string    `json:"name,omitempty"`
 	ParentID   string    `json:"parent_id,omitempty"`
-	Color      string    `json:"color,omitempty"`
+	Color      Color     `json:"color,omitempty"`
 	IsFavorite bool      `json:"is_favorite,omitempty"`
 	ViewStyle  ViewStyle `json:"view_style,omitempty"`
 }
@@ -53,12 +53,13 @@ type Task struct {
 	ID           string        `json:"id"`
 	ProjectID    string        `json:"project_id"`
 	SectionID    string        `json:"section_id"`
+	ParentID     string        `json:"parent_id,omitempty"`
 	Content      string        `json:"content"`
 	Description  string        `json:"description,omitempty"`
 	IsCompleted  bool          `json:"is_completed"`
 	Labels       []string      `json:"labels,omitempty"`
 	Order        int           `json:"order"`
-	Priority     int           `json:"priority"`
+	Priority     Priority      `json:"priority"`
 	AssigneeID   string        `json:"assignee_id,omitempty"`
 	AssignerID   string        `json:"assigner_id,omitempty"`
 	CommentCount int           `json:"comment_count,omitempty"`
@@ -78,25 +79,33 @@ type TaskDue struct {
 
 // TaskDuration represents the duration of a task.
 type TaskDuration struct {
-	Amount int    `json:"amount"`
-	Unit   string `json:"unit"`
+	Amount int          `json:"amount"`
+	Unit   DurationUnit `json:"unit"`
 }
 
 // TaskParams defines the parameters for creating and updating a task.
 type TaskParams struct {
-	Content      string   `json:"content,omitempty"`
-	Description  string   `json:"description,omitempty"`
-	ProjectID    string   `json:"project_id,omitempty"`
-	SectionID    string   `json:"section_id,omitempty"`
-	Priority     int      `json:"priority,omitempty"`
-	Labels       []string `json:"labels,omitempty"`
-	DueString    string   `json:"due_string,omitempty"`
-	DueDate      string   `json:"due_date,omitempty"`
-	DueDatetime  string   `json:"due_datetime,omitempty"`
-	DueLang      string   `json:"due_lang,omitempty"`
-	AssigneeID   string   `json:"assignee_id,omitempty"`
-	Duration     int      `json:"duration,omitempty"`
-	DurationUnit string   `json:"duration_unit,omitempty"`
+	Content      string       `json:"content,omitempty"`
+	Description  string       `json:"description,omitempty"`
+	ProjectID    string       `json:"project_id,omitempty"`
+	SectionID    string       `json:"section_id,omitempty"`
+	ParentID     string       `json:"parent_id,omitempty"`
+	Priority     Priority     `json:"priority,omitempty"`
+	Labels       []string     `json:"labels,omitempty"`
+	DueString    string       `json:"due_string,omitempty"`
+	DueDate      string       `json:"due_date,omitempty"`
+	DueDatetime  string       `json:"due_datetime,omitempty"`
+	DueLang      string       `json:"due_lang,omitempty"`
+	AssigneeID   string       `json:"assignee_id,omitempty"`
+	Duration     int          `json:"duration,omitempty"`
+	DurationUnit DurationUnit `json:"duration_unit,omitempty"`
+}
+
+// MoveTaskParams defines where a task is moved to. Exactly one of the fields must be set.
+type MoveTaskParams struct {
+	ProjectID string `json:"project_id,omitempty"`
+	SectionID string `json:"section_id,omitempty"`
+	ParentID  string `json:"parent_id,omitempty"`
 }
 
 // Comment represents a comment from the Todoist API.
@@ -112,9 +121,14 @@ type Comment struct {
 // Attachment represents an optional attachment in a comment.
 type Attachment struct {
 	FileName     string `json:"file_name,omitempty"`
+	FileSize     int64  `json:"file_size,omitempty"`
 	FileType     string `json:"file_type,omitempty"`
 	FileURL      string `json:"file_url,omitempty"`
 	ResourceType string `json:"resource_type,omitempty"`
+	UploadState  string `json:"upload_state,omitempty"`
+	Image        string `json:"image,omitempty"`
+	ImageWidth   int    `json:"image_width,omitempty"`
+	ImageHeight  int    `json:"image_height,omitempty"`
 }
 
 // CommentParams defines the parameters for creating and updating a comment.
@@ -129,7 +143,7 @@ type CommentParams struct {
 type Label struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
-	Color      string `json:"color"`
+	Color      Color  `json:"color"`
 	Order      int    `json:"order"`
 	IsFavorite bool   `json:"is_favorite"`
 }
@@ -137,7 +151,7 @@ type Label struct {
 // LabelParams defines the parameters for creating and updating a label.
 type LabelParams struct {
 	Name       string `json:"name,omitempty"`
-	Color      string `json:"color,omitempty"`
+	Color      Color  `json:"color,omitempty"`
 	Order      int    `json:"order,omitempty"`
 	IsFavorite bool   `json:"is_favorite,omitempty"`
 }
@@ -0,0 +1,213 @@
+package todoist
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TokenSource provides the access token for each request, allowing tokens to come from storage or to be rotated.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface.
+type TokenSourceFunc func() (string, error)
+
+// Token calls f.
+func (f TokenSourceFunc) Token() (string, error) {
+	return f()
+}
+
+// StaticTokenSource returns a TokenSource that always returns the same token.
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func() (string, error) {
+		return token, nil
+	})
+}
+
+// Scope is an OAuth permission requested from a Todoist user.
+type Scope string
+
+const (
+	ScopeTaskAdd       Scope = "task:add"
+	ScopeDataRead      Scope = "data:read"
+	ScopeDataReadWrite Scope = "data:read_write"
+	ScopeDataDelete    Scope = "data:delete"
+	ScopeProjectDelete Scope = "project:delete"
+)
+
+// ErrOAuthStateMismatch is returned when the state of an OAuth callback does not match the expected state.
+var ErrOAuthStateMismatch = errors.New("oauth state mismatch")
+
+// OAuthError is an error reported by the Todoist OAuth endpoints, e.g. "access_denied" or "bad_authorization_code".
+type OAuthError struct {
+	Code string `json:"error"`
+}
+
+func (e *OAuthError) Error() string {
+	return "oauth error: " + e.Code
+}
+
+// OAuthConfig describes a Todoist app for the OAuth2 authorization code flow.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []Scope
+
+	AuthorizeURL string // Defaults to https://todoist.com/oauth/authorize
+	TokenURL     string // Defaults to https://todoist.com/oauth/access_token
+	RevokeURL    string // Defaults to https://api.todoist.com/sync/v9/access_tokens/revoke
+	HTTPClient   *http.Client
+}
+
+// OAuthToken is an access token issued to the app. Todoist tokens do not expire until they are revoked.
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// TokenSource returns a TokenSource for the access token.
+func (t *OAuthToken) TokenSource() TokenSource {
+	return StaticTokenSource(t.AccessToken)
+}
+
+// NewOAuthState generates a random state value to protect the authorization flow against CSRF.
+func NewOAuthState() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the URL to send the user to in order to authorize the app.
+func (c *OAuthConfig) AuthCodeURL(state string) string {
+	scopes := make([]string, len(c.Scopes))
+	for i, scope := range c.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	query := url.Values{}
+	query.Set("client_id", c.ClientID)
+	query.Set("scope", strings.Join(scopes, ","))
+	query.Set("state", state)
+
+	return c.authorizeURL() + "?" + query.Encode()
+}
+
+// HandleCallback checks the redirect request Todoist sends after authorization against the expected state
+// and exchanges the contained code for an access token.
+func (c *OAuthConfig) HandleCallback(ctx context.Context, r *http.Request, expectedState string) (*OAuthToken, error) {
+	query := r.URL.Query()
+
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(expectedState)) != 1 || expectedState == "" {
+		return nil, ErrOAuthStateMismatch
+	}
+	if code := query.Get("error"); code != "" {
+		return nil, &OAuthError{Code: code}
+	}
+
+	return c.Exchange(ctx, query.Get("code"))
+}
+
+// Exchange trades an authorization code for an access token.
+func (c *OAuthConfig) Exchange(ctx context.Context, code string) (*OAuthToken, error) {
+	if code == "" {
+		return nil, errors.New("authorization code is empty")
+	}
+
+	form := url.Values{}
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	form.Set("code", code)
+
+	resp, err := c.postForm(ctx, c.tokenURL(), form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var token struct {
+		OAuthToken
+		OAuthError
+	}
+	if err := json.Unmarshal(body, &token); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+	if token.Code != "" {
+		return nil, &OAuthError{Code: token.Code}
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return nil, fmt.Errorf("failed to exchange authorization code, status code: %d", resp.StatusCode)
+	}
+
+	return &token.OAuthToken, nil
+}
+
+// Revoke invalidates an access token issued to the app.
+func (c *OAuthConfig) Revoke(ctx context.Context, accessToken string) error {
+	form := url.Values{}
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	form.Set("access_token", accessToken)
+
+	resp, err := c.postForm(ctx, c.revokeURL(), form)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body.Close()
+	}
+	return checkNoContent(resp, "revoke access token")
+}
+
+// postForm sends form encoded parameters to an OAuth endpoint.
+func (c *OAuthConfig) postForm(ctx context.Context, endpoint string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+func (c *OAuthConfig) authorizeURL() string {
+	if c.AuthorizeURL != "" {
+		return c.AuthorizeURL
+	}
+	return "https://todoist.com/oauth/authorize"
+}
+
+func (c *OAuthConfig) tokenURL() string {
+	if c.TokenURL != "" {
+		return c.TokenURL
+	}
+	return "https://todoist.com/oauth/access_token"
+}
+
+func (c *OAuthConfig) revokeURL() string {
+	if c.RevokeURL != "" {
+		return c.RevokeURL
+	}
+	return "https://api.todoist.com/sync/v9/access_tokens/revoke"
+}
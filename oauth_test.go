@@ -0,0 +1,153 @@
+package todoist_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/todoisttest"
+)
+
+// authorize follows the authorization URL of config as an approving user and returns the code and state
+// that Todoist sends back.
+func authorize(t *testing.T, server *todoisttest.OAuthServer, config *todoist.OAuthConfig, state string) (code, gotState string) {
+	t.Helper()
+
+	resp, err := server.Client().Get(config.AuthCodeURL(state))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var grant struct{ Code, State string }
+	if err := json.NewDecoder(resp.Body).Decode(&grant); err != nil {
+		t.Fatal(err)
+	}
+	return grant.Code, grant.State
+}
+
+func callback(query url.Values) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/callback?"+query.Encode(), nil)
+}
+
+func TestOAuthFlow(t *testing.T) {
+	server := todoisttest.NewOAuthServer("app", "secret")
+	defer server.Close()
+	config := server.Config(todoist.ScopeDataRead, todoist.ScopeTaskAdd)
+
+	state, err := todoist.NewOAuthState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, gotState := authorize(t, server, config, state)
+	if gotState != state {
+		t.Fatalf("state %q came back as %q", state, gotState)
+	}
+
+	token, err := config.HandleCallback(context.Background(), callback(url.Values{"code": {code}, "state": {gotState}}), state)
+	if err != nil {
+		t.Fatalf("HandleCallback: %v", err)
+	}
+	if !server.ValidToken(token.AccessToken) || len(server.Scopes(token.AccessToken)) != 2 {
+		t.Errorf("token %+v not issued with the requested scopes", token)
+	}
+
+	// Codes are single use.
+	if _, err := config.Exchange(context.Background(), code); err == nil {
+		t.Error("second exchange of the same code succeeded")
+	}
+
+	if err := config.Revoke(context.Background(), token.AccessToken); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if server.ValidToken(token.AccessToken) {
+		t.Error("token is still valid after Revoke")
+	}
+}
+
+func TestOAuthCallbackErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func(code string) url.Values
+		expected string
+		secret   string
+		check    func(error) bool
+	}{
+		{
+			name:     "mismatched state",
+			query:    func(code string) url.Values { return url.Values{"code": {code}, "state": {"forged"}} },
+			expected: "state",
+			check:    func(err error) bool { return errors.Is(err, todoist.ErrOAuthStateMismatch) },
+		},
+		{
+			name:     "missing state",
+			query:    func(code string) url.Values { return url.Values{"code": {code}} },
+			expected: "state",
+			check:    func(err error) bool { return errors.Is(err, todoist.ErrOAuthStateMismatch) },
+		},
+		{
+			name:     "empty expected state",
+			query:    func(code string) url.Values { return url.Values{"code": {code}, "state": {""}} },
+			expected: "",
+			check:    func(err error) bool { return errors.Is(err, todoist.ErrOAuthStateMismatch) },
+		},
+		{
+			name:     "access denied",
+			query:    func(string) url.Values { return url.Values{"error": {"access_denied"}, "state": {"state"}} },
+			expected: "state",
+			check:    func(err error) bool { return isOAuthError(err, "access_denied") },
+		},
+		{
+			name:     "unknown code",
+			query:    func(string) url.Values { return url.Values{"code": {"made-up"}, "state": {"state"}} },
+			expected: "state",
+			check:    func(err error) bool { return isOAuthError(err, "bad_authorization_code") },
+		},
+		{
+			name:     "missing code",
+			query:    func(string) url.Values { return url.Values{"state": {"state"}} },
+			expected: "state",
+			check:    func(err error) bool { return err != nil },
+		},
+		{
+			name:     "wrong client secret",
+			query:    func(code string) url.Values { return url.Values{"code": {code}, "state": {"state"}} },
+			expected: "state",
+			secret:   "wrong",
+			check:    func(err error) bool { return isOAuthError(err, "bad_client_credentials") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := todoisttest.NewOAuthServer("app", "secret")
+			defer server.Close()
+			config := server.Config(todoist.ScopeDataRead)
+			if tt.secret != "" {
+				config.ClientSecret = tt.secret
+			}
+			code := server.IssueCode(todoist.ScopeDataRead)
+
+			token, err := config.HandleCallback(context.Background(), callback(tt.query(code)), tt.expected)
+			if token != nil || !tt.check(err) {
+				t.Errorf("HandleCallback = %+v, %v", token, err)
+			}
+			// A callback with the wrong state must not use up the code.
+			if errors.Is(err, todoist.ErrOAuthStateMismatch) {
+				if _, err := config.Exchange(context.Background(), code); err != nil {
+					t.Errorf("code was exchanged despite the state mismatch: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func isOAuthError(err error, code string) bool {
+	var oauthErr *todoist.OAuthError
+	return errors.As(err, &oauthErr) && oauthErr.Code == code
+}
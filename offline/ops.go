@@ -0,0 +1,208 @@
+package offline
+
+import (
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// CreateProject queues the creation of a project and returns it with a temporary ID.
+func (q *Queue) CreateProject(params todoist.ProjectParams) (*todoist.Project, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	id, err := q.enqueue(OpCreateProject, "", params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &todoist.Project{
+		ID:         id,
+		Name:       params.Name,
+		Color:      params.Color,
+		IsFavorite: params.IsFavorite,
+		ViewStyle:  params.ViewStyle,
+	}
+	if params.ParentID != "" {
+		project.ParentID = &params.ParentID
+	}
+	return project, nil
+}
+
+// UpdateProject queues an update of a project.
+func (q *Queue) UpdateProject(id string, params todoist.ProjectParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	_, err := q.enqueue(OpUpdateProject, id, params, false)
+	return err
+}
+
+// DeleteProject queues the deletion of a project.
+func (q *Queue) DeleteProject(id string) error {
+	_, err := q.enqueue(OpDeleteProject, id, nil, false)
+	return err
+}
+
+// CreateSection queues the creation of a section and returns it with a temporary ID.
+func (q *Queue) CreateSection(params todoist.SectionParams) (*todoist.Section, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	id, err := q.enqueue(OpCreateSection, "", params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todoist.Section{
+		ID:        id,
+		ProjectID: params.ProjectID,
+		Order:     params.Order,
+		Name:      params.Name,
+	}, nil
+}
+
+// UpdateSection queues an update of a section.
+func (q *Queue) UpdateSection(id string, params todoist.SectionParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	_, err := q.enqueue(OpUpdateSection, id, params, false)
+	return err
+}
+
+// DeleteSection queues the deletion of a section.
+func (q *Queue) DeleteSection(id string) error {
+	_, err := q.enqueue(OpDeleteSection, id, nil, false)
+	return err
+}
+
+// CreateTask queues the creation of a task and returns it with a temporary ID.
+func (q *Queue) CreateTask(params todoist.TaskParams) (*todoist.Task, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	id, err := q.enqueue(OpCreateTask, "", params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &todoist.Task{
+		ID:          id,
+		ProjectID:   params.ProjectID,
+		SectionID:   params.SectionID,
+		ParentID:    params.ParentID,
+		Content:     params.Content,
+		Description: params.Description,
+		Labels:      params.Labels,
+		Priority:    params.Priority,
+		AssigneeID:  params.AssigneeID,
+	}
+	if params.Duration > 0 {
+		task.Duration = &todoist.TaskDuration{Amount: params.Duration, Unit: params.DurationUnit}
+	}
+	return task, nil
+}
+
+// UpdateTask queues an update of a task.
+func (q *Queue) UpdateTask(id string, params todoist.TaskParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	_, err := q.enqueue(OpUpdateTask, id, params, false)
+	return err
+}
+
+// CloseTask queues closing a task.
+func (q *Queue) CloseTask(id string) error {
+	_, err := q.enqueue(OpCloseTask, id, nil, false)
+	return err
+}
+
+// ReopenTask queues reopening a task.
+func (q *Queue) ReopenTask(id string) error {
+	_, err := q.enqueue(OpReopenTask, id, nil, false)
+	return err
+}
+
+// DeleteTask queues the deletion of a task.
+func (q *Queue) DeleteTask(id string) error {
+	_, err := q.enqueue(OpDeleteTask, id, nil, false)
+	return err
+}
+
+// CreateComment queues the creation of a comment and returns it with a temporary ID.
+func (q *Queue) CreateComment(params todoist.CommentParams) (*todoist.Comment, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	id, err := q.enqueue(OpCreateComment, "", params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todoist.Comment{
+		ID:         id,
+		TaskID:     params.TaskID,
+		ProjectID:  params.ProjectID,
+		Content:    params.Content,
+		Attachment: params.Attachment,
+	}, nil
+}
+
+// UpdateComment queues an update of a comment.
+func (q *Queue) UpdateComment(id string, params todoist.CommentParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	_, err := q.enqueue(OpUpdateComment, id, params, false)
+	return err
+}
+
+// DeleteComment queues the deletion of a comment.
+func (q *Queue) DeleteComment(id string) error {
+	_, err := q.enqueue(OpDeleteComment, id, nil, false)
+	return err
+}
+
+// CreateLabel queues the creation of a personal label and returns it with a temporary ID.
+func (q *Queue) CreateLabel(params todoist.LabelParams) (*todoist.Label, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	id, err := q.enqueue(OpCreateLabel, "", params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todoist.Label{
+		ID:         id,
+		Name:       params.Name,
+		Color:      params.Color,
+		Order:      params.Order,
+		IsFavorite: params.IsFavorite,
+	}, nil
+}
+
+// UpdateLabel queues an update of a personal label.
+func (q *Queue) UpdateLabel(id string, params todoist.LabelParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	_, err := q.enqueue(OpUpdateLabel, id, params, false)
+	return err
+}
+
+// DeleteLabel queues the deletion of a personal label.
+func (q *Queue) DeleteLabel(id string) error {
+	_, err := q.enqueue(OpDeleteLabel, id, nil, false)
+	return err
+}
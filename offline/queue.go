@@ -0,0 +1,206 @@
+// Package offline records mutations in a durable journal while there is no connection and replays them later.
+package offline
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// OpKind names the client method a queued operation replays.
+type OpKind string
+
+const (
+	OpCreateProject OpKind = "create_project"
+	OpUpdateProject OpKind = "update_project"
+	OpDeleteProject OpKind = "delete_project"
+	OpCreateSection OpKind = "create_section"
+	OpUpdateSection OpKind = "update_section"
+	OpDeleteSection OpKind = "delete_section"
+	OpCreateTask    OpKind = "create_task"
+	OpUpdateTask    OpKind = "update_task"
+	OpCloseTask     OpKind = "close_task"
+	OpReopenTask    OpKind = "reopen_task"
+	OpDeleteTask    OpKind = "delete_task"
+	OpCreateComment OpKind = "create_comment"
+	OpUpdateComment OpKind = "update_comment"
+	OpDeleteComment OpKind = "delete_comment"
+	OpCreateLabel   OpKind = "create_label"
+	OpUpdateLabel   OpKind = "update_label"
+	OpDeleteLabel   OpKind = "delete_label"
+)
+
+// tempIDPrefix marks IDs handed out by the queue for resources that do not exist on Todoist yet.
+const tempIDPrefix = "tmp_"
+
+// ErrUnresolvedID is reported when an operation refers to a temporary ID whose create operation was dropped.
+var ErrUnresolvedID = errors.New("temporary ID was never created on Todoist")
+
+// ErrInvalidOp is reported for a journal entry that cannot be replayed, such as one of an unknown kind
+// written by a newer version. Retrying it does not help.
+var ErrInvalidOp = errors.New("invalid queued operation")
+
+// Op is a queued mutation as stored in the journal.
+type Op struct {
+	Seq      int64           `json:"seq"`
+	Kind     OpKind          `json:"kind"`
+	ID       string          `json:"id,omitempty"`      // ID of the resource the operation applies to
+	TempID   string          `json:"temp_id,omitempty"` // ID handed out for a resource created by the operation
+	Params   json.RawMessage `json:"params,omitempty"`
+	QueuedAt time.Time       `json:"queued_at"`
+}
+
+// journal is the on-disk format of a queue.
+type journal struct {
+	Seq int64             `json:"seq"`
+	Ops []Op              `json:"ops"`
+	IDs map[string]string `json:"ids"` // Temporary IDs mapped to the real IDs assigned during replay
+}
+
+// Queue records client mutations in a journal file so they can be replayed once a connection is available.
+type Queue struct {
+	client *todoist.TodoistClient
+	path   string
+
+	mu      sync.Mutex
+	journal journal
+}
+
+// IsTempID reports whether id was handed out by a Queue and does not exist on Todoist yet.
+func IsTempID(id string) bool {
+	return strings.HasPrefix(id, tempIDPrefix)
+}
+
+// Open loads the journal at path, creating an empty one if it does not exist.
+func Open(path string, client *todoist.TodoistClient) (*Queue, error) {
+	q := &Queue{
+		client:  client,
+		path:    path,
+		journal: journal{IDs: make(map[string]string)},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &q.journal); err != nil {
+		return nil, fmt.Errorf("corrupt offline journal %s: %w", path, err)
+	}
+	if q.journal.IDs == nil {
+		q.journal.IDs = make(map[string]string)
+	}
+
+	return q, nil
+}
+
+// Pending returns the operations that have not been replayed yet, in order.
+func (q *Queue) Pending() []Op {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]Op(nil), q.journal.Ops...)
+}
+
+// Len returns the number of operations waiting to be replayed.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.journal.Ops)
+}
+
+// ResolveID returns the real ID for a temporary ID once its create operation has been replayed.
+func (q *Queue) ResolveID(id string) (string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !IsTempID(id) {
+		return id, true
+	}
+	realID, ok := q.journal.IDs[id]
+	return realID, ok
+}
+
+// enqueue appends an operation to the journal and persists it before returning.
+func (q *Queue) enqueue(kind OpKind, id string, params interface{}, create bool) (string, error) {
+	var raw json.RawMessage
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return "", err
+		}
+		raw = data
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	op := Op{Kind: kind, ID: id, Params: raw, QueuedAt: time.Now()}
+	if create {
+		tempID, err := newTempID()
+		if err != nil {
+			return "", err
+		}
+		op.TempID = tempID
+	}
+
+	q.journal.Seq++
+	op.Seq = q.journal.Seq
+	q.journal.Ops = append(q.journal.Ops, op)
+
+	if err := q.saveLocked(); err != nil {
+		q.journal.Ops = q.journal.Ops[:len(q.journal.Ops)-1]
+		return "", err
+	}
+	return op.TempID, nil
+}
+
+// saveLocked atomically writes the journal to disk. The caller must hold q.mu.
+func (q *Queue) saveLocked() error {
+	data, err := json.MarshalIndent(q.journal, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), filepath.Base(q.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), q.path)
+}
+
+// newTempID generates a random temporary ID.
+func newTempID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return tempIDPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,266 @@
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// maxRetries limits how often a single operation is retried on request of a Resolver.
+const maxRetries = 3
+
+// Resolution tells Replay how to continue after a conflict.
+type Resolution int
+
+const (
+	// Abort stops the replay and keeps the conflicting operation and all later ones in the journal.
+	Abort Resolution = iota
+	// Skip drops the conflicting operation and continues with the next one.
+	Skip
+	// Retry runs the conflicting operation again. Operations that cannot be read from the journal are not retried.
+	Retry
+)
+
+// Conflict describes an operation that was rejected by the API during replay.
+type Conflict struct {
+	Op  Op
+	Err error
+}
+
+// NotFound reports whether the operation failed because its target no longer exists, e.g. it was deleted remotely.
+func (c Conflict) NotFound() bool {
+	var apiErr *todoist.APIError
+	if errors.As(c.Err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone
+	}
+	return errors.Is(c.Err, ErrUnresolvedID)
+}
+
+// Resolver decides how to handle a conflict. It must not call methods of the Queue being replayed.
+type Resolver func(Conflict) Resolution
+
+// ReplayResult summarizes a replay.
+type ReplayResult struct {
+	Applied   int
+	Skipped   []Op
+	Remaining int
+}
+
+// Replay sends the queued operations to Todoist in order, replacing temporary IDs with the real ones as they are created.
+// Operations rejected by the API, and operations that cannot be read from the journal, are passed to resolve;
+// a nil resolver aborts on the first conflict.
+// Transient failures such as network errors, rate limiting or server errors stop the replay and return the error,
+// leaving the remaining operations in the journal for the next attempt.
+func (q *Queue) Replay(resolve Resolver) (*ReplayResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	result := &ReplayResult{}
+	defer func() { result.Remaining = len(q.journal.Ops) }()
+
+	attempts := 0
+	for len(q.journal.Ops) > 0 {
+		op := q.journal.Ops[0]
+
+		if _, created := q.journal.IDs[op.TempID]; op.TempID != "" && created {
+			// The resource was created by an earlier replay that stopped before dropping the operation.
+			result.Applied++
+		} else if realID, err := q.execute(op); err != nil {
+			if !isConflict(err) {
+				return result, fmt.Errorf("replay of %s (seq %d) failed: %w", op.Kind, op.Seq, err)
+			}
+
+			resolution := Abort
+			if resolve != nil {
+				resolution = resolve(Conflict{Op: op, Err: err})
+			}
+
+			switch {
+			case resolution == Retry && attempts < maxRetries && !errors.Is(err, ErrInvalidOp):
+				attempts++
+				continue
+			case resolution == Skip:
+				result.Skipped = append(result.Skipped, op)
+			default:
+				return result, fmt.Errorf("replay of %s (seq %d) aborted: %w", op.Kind, op.Seq, err)
+			}
+		} else {
+			if op.TempID != "" {
+				// The real ID is saved before the operation is dropped, so that an interrupted replay
+				// does not create the resource again.
+				q.journal.IDs[op.TempID] = realID
+				if err := q.saveLocked(); err != nil {
+					return result, err
+				}
+			}
+			result.Applied++
+		}
+
+		attempts = 0
+		q.journal.Ops = q.journal.Ops[1:]
+		if err := q.saveLocked(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// isConflict reports whether err means the operation itself was rejected, rather than the request failing.
+func isConflict(err error) bool {
+	var apiErr *todoist.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests
+	}
+
+	var validationErrs todoist.ValidationErrors
+	return errors.As(err, &validationErrs) || errors.Is(err, ErrUnresolvedID) || errors.Is(err, ErrInvalidOp)
+}
+
+// resolveLocked replaces temporary IDs in place with the real IDs assigned during replay. The caller must hold q.mu.
+func (q *Queue) resolveLocked(ids ...*string) error {
+	for _, id := range ids {
+		if !IsTempID(*id) {
+			continue
+		}
+		realID, ok := q.journal.IDs[*id]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnresolvedID, *id)
+		}
+		*id = realID
+	}
+	return nil
+}
+
+// execute runs a single operation against the API and returns the ID of the created resource, if any.
+// The caller must hold q.mu.
+func (q *Queue) execute(op Op) (string, error) {
+	id := op.ID
+	if err := q.resolveLocked(&id); err != nil {
+		return "", err
+	}
+
+	switch op.Kind {
+	case OpCreateProject, OpUpdateProject:
+		var params todoist.ProjectParams
+		if err := decodeParams(op, &params); err != nil {
+			return "", err
+		}
+		if err := q.resolveLocked(&params.ParentID); err != nil {
+			return "", err
+		}
+		if op.Kind == OpUpdateProject {
+			_, err := q.client.UpdateProject(id, params)
+			return "", err
+		}
+		project, err := q.client.CreateProject(params)
+		if err != nil {
+			return "", err
+		}
+		return project.ID, nil
+
+	case OpCreateSection, OpUpdateSection:
+		var params todoist.SectionParams
+		if err := decodeParams(op, &params); err != nil {
+			return "", err
+		}
+		if err := q.resolveLocked(&params.ProjectID); err != nil {
+			return "", err
+		}
+		if op.Kind == OpUpdateSection {
+			_, err := q.client.UpdateSection(id, params)
+			return "", err
+		}
+		section, err := q.client.CreateSection(params)
+		if err != nil {
+			return "", err
+		}
+		return section.ID, nil
+
+	case OpCreateTask, OpUpdateTask:
+		var params todoist.TaskParams
+		if err := decodeParams(op, &params); err != nil {
+			return "", err
+		}
+		if err := q.resolveLocked(&params.ProjectID, &params.SectionID, &params.ParentID); err != nil {
+			return "", err
+		}
+		if op.Kind == OpUpdateTask {
+			_, err := q.client.UpdateTask(id, params)
+			return "", err
+		}
+		task, err := q.client.CreateTask(params)
+		if err != nil {
+			return "", err
+		}
+		return task.ID, nil
+
+	case OpCreateComment, OpUpdateComment:
+		var params todoist.CommentParams
+		if err := decodeParams(op, &params); err != nil {
+			return "", err
+		}
+		if err := q.resolveLocked(&params.TaskID, &params.ProjectID); err != nil {
+			return "", err
+		}
+		if op.Kind == OpUpdateComment {
+			_, err := q.client.UpdateComment(id, params)
+			return "", err
+		}
+		comment, err := q.client.CreateComment(params)
+		if err != nil {
+			return "", err
+		}
+		return comment.ID, nil
+
+	case OpCreateLabel, OpUpdateLabel:
+		var params todoist.LabelParams
+		if err := decodeParams(op, &params); err != nil {
+			return "", err
+		}
+		if op.Kind == OpUpdateLabel {
+			_, err := q.client.UpdateLabel(id, params)
+			return "", err
+		}
+		label, err := q.client.CreateLabel(params)
+		if err != nil {
+			return "", err
+		}
+		return label.ID, nil
+
+	case OpDeleteProject:
+		_, err := q.client.DeleteProject(id)
+		return "", err
+	case OpDeleteSection:
+		_, err := q.client.DeleteSection(id)
+		return "", err
+	case OpCloseTask:
+		_, err := q.client.CloseTask(id)
+		return "", err
+	case OpReopenTask:
+		_, err := q.client.ReopenTask(id)
+		return "", err
+	case OpDeleteTask:
+		_, err := q.client.DeleteTask(id)
+		return "", err
+	case OpDeleteComment:
+		_, err := q.client.DeleteComment(id)
+		return "", err
+	case OpDeleteLabel:
+		_, err := q.client.DeleteLabel(id)
+		return "", err
+	}
+
+	return "", fmt.Errorf("%w: unknown kind %q", ErrInvalidOp, op.Kind)
+}
+
+// decodeParams reads the parameters of an operation.
+func decodeParams(op Op, params interface{}) error {
+	if err := json.Unmarshal(op.Params, params); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOp, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Apply carries out the actions of a plan returned by Compute, in order, and sets the ID of
+// every created record. Updates are sent as Sync API commands, which unlike the REST API can
+// also turn settings off.
+//
+// If a request fails, Apply stops and returns the error; the actions before it have been
+// applied, and computing the plan again picks up from there.
+func Apply(client *todoist.TodoistClient, p *Plan) error {
+	projectIDs := make(map[string]string, len(p.projectIDs))
+	for name, id := range p.projectIDs {
+		projectIDs[name] = id
+	}
+
+	for i := range p.Actions {
+		a := &p.Actions[i]
+		var err error
+		switch {
+		case a.Action == Create && a.Type == TypeLabel:
+			err = createLabel(client, a)
+		case a.Action == Create && a.Type == TypeProject:
+			err = createProject(client, a, projectIDs)
+			if err == nil {
+				projectIDs[a.Name] = a.ID
+			}
+		case a.Action == Create && a.Type == TypeSection:
+			var section *todoist.Section
+			section, err = client.CreateSection(todoist.SectionParams{ProjectID: projectIDs[a.Project], Name: a.Name})
+			if err == nil {
+				a.ID = section.ID
+			}
+		case a.Action == Update:
+			err = update(client, a, projectIDs)
+		case a.Action == Delete && a.Type == TypeLabel:
+			_, err = client.DeleteLabel(a.ID)
+		case a.Action == Delete && a.Type == TypeProject:
+			_, err = client.DeleteProject(a.ID)
+		case a.Action == Delete && a.Type == TypeSection:
+			_, err = client.DeleteSection(a.ID)
+		default:
+			err = errors.New("unknown action")
+		}
+		if err != nil {
+			return fmt.Errorf("plan: %s %s %q: %w", a.Action, a.Type, a.Name, err)
+		}
+	}
+	return nil
+}
+
+func createLabel(client *todoist.TodoistClient, a *Action) error {
+	params := todoist.LabelParams{Name: a.label.Name, Color: a.label.Color}
+	if a.label.Favorite != nil {
+		params.IsFavorite = *a.label.Favorite
+	}
+	label, err := client.CreateLabel(params)
+	if err != nil {
+		return err
+	}
+	a.ID = label.ID
+	return nil
+}
+
+func createProject(client *todoist.TodoistClient, a *Action, projectIDs map[string]string) error {
+	want := a.project
+	params := todoist.ProjectParams{Name: want.Name, Color: want.Color, ViewStyle: want.ViewStyle}
+	if want.Parent != "" {
+		params.ParentID = projectIDs[want.Parent]
+	}
+	if want.Favorite != nil {
+		params.IsFavorite = *want.Favorite
+	}
+	project, err := client.CreateProject(params)
+	if err != nil {
+		return err
+	}
+	a.ID = project.ID
+	return nil
+}
+
+// update sends the changed settings of a project or label, and moves a project whose parent changed.
+func update(client *todoist.TodoistClient, a *Action, projectIDs map[string]string) error {
+	args := map[string]interface{}{"id": a.ID}
+	move := false
+	for _, c := range a.Changes {
+		switch c.Field {
+		case "color":
+			args["color"] = c.New
+		case "view_style":
+			args["view_style"] = c.New
+		case "favorite":
+			args["is_favorite"] = c.New == "true"
+		case "parent":
+			move = true
+		}
+	}
+
+	if len(args) > 1 {
+		if _, err := client.ExecuteCommands(todoist.NewCommand(a.Type+"_update", args)); err != nil {
+			return err
+		}
+	}
+	if move {
+		if _, err := client.MoveProject(a.ID, projectIDs[a.project.Parent]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
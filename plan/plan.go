@@ -0,0 +1,318 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// ActionType is what an action does.
+type ActionType string
+
+const (
+	Create ActionType = "create"
+	Update ActionType = "update"
+	Delete ActionType = "delete"
+)
+
+// Types of records an action applies to.
+const (
+	TypeProject = "project"
+	TypeSection = "section"
+	TypeLabel   = "label"
+)
+
+// Action is a change to one project, section or label.
+type Action struct {
+	Action ActionType `json:"action"`
+	Type   string     `json:"type"`
+	Name   string     `json:"name"`
+	// Project is the name of the project of a section.
+	Project string `json:"project,omitempty"`
+	// ID is the ID of the record, set by Apply for created records.
+	ID      string   `json:"id,omitempty"`
+	Changes []Change `json:"changes,omitempty"`
+
+	project *ProjectSpec
+	label   *LabelSpec
+}
+
+// Change is a field changed by an update.
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Plan is the list of actions bringing an account to the state of a spec, in the order Apply
+// carries them out: labels, projects with their parents first, sections and finally deletions.
+type Plan struct {
+	Actions []Action `json:"actions"`
+
+	// projectIDs maps the names of the spec's existing projects to their IDs.
+	projectIDs map[string]string
+}
+
+// Options configures Compute.
+type Options struct {
+	// Prune deletes the projects and labels that are not part of the spec, and the sections of
+	// managed projects that are not listed. The Inbox is never deleted.
+	Prune bool
+}
+
+// Compute diffs a spec against the account of client.
+func Compute(client *todoist.TodoistClient, spec *Spec, opts Options) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	projects, err := client.GetProjects()
+	if err != nil {
+		return nil, fmt.Errorf("plan: list projects: %w", err)
+	}
+	labels, err := client.GetLabels()
+	if err != nil {
+		return nil, fmt.Errorf("plan: list labels: %w", err)
+	}
+
+	p := &Plan{projectIDs: make(map[string]string)}
+	p.labels(spec, labels)
+	matched := p.projects(spec, projects)
+	if err := p.sections(client, spec, opts); err != nil {
+		return nil, err
+	}
+	if opts.Prune {
+		p.pruneProjects(projects, matched)
+		p.pruneLabels(spec, labels)
+	}
+	return p, nil
+}
+
+func (p *Plan) labels(spec *Spec, labels []todoist.Label) {
+	existing := make(map[string]todoist.Label, len(labels))
+	for _, l := range labels {
+		existing[l.Name] = l
+	}
+	for i := range spec.Labels {
+		want := &spec.Labels[i]
+		l, ok := existing[want.Name]
+		if !ok {
+			p.Actions = append(p.Actions, Action{Action: Create, Type: TypeLabel, Name: want.Name, label: want})
+			continue
+		}
+		var changes []Change
+		if want.Color != "" && want.Color != l.Color {
+			changes = append(changes, Change{"color", string(l.Color), string(want.Color)})
+		}
+		if want.Favorite != nil && *want.Favorite != l.IsFavorite {
+			changes = append(changes, Change{"favorite", strconv.FormatBool(l.IsFavorite), strconv.FormatBool(*want.Favorite)})
+		}
+		if len(changes) > 0 {
+			p.Actions = append(p.Actions, Action{Action: Update, Type: TypeLabel, Name: want.Name, ID: l.ID, Changes: changes, label: want})
+		}
+	}
+}
+
+// projects plans the projects of the spec, parents first, and returns the IDs of the existing
+// projects it manages.
+func (p *Plan) projects(spec *Spec, projects []todoist.Project) map[string]bool {
+	byID := make(map[string]todoist.Project, len(projects))
+	byName := make(map[string][]todoist.Project)
+	for _, project := range projects {
+		byID[project.ID] = project
+		byName[project.Name] = append(byName[project.Name], project)
+	}
+	parentName := func(project todoist.Project) string {
+		if project.ParentID == nil {
+			return ""
+		}
+		return byID[*project.ParentID].Name
+	}
+
+	matched := make(map[string]bool)
+	for _, want := range sortProjects(spec.Projects) {
+		// Of several projects with the name, the one below the right parent is preferred.
+		var found *todoist.Project
+		for i, candidate := range byName[want.Name] {
+			if matched[candidate.ID] {
+				continue
+			}
+			if parentName(candidate) == want.Parent {
+				found = &byName[want.Name][i]
+				break
+			}
+			if found == nil {
+				found = &byName[want.Name][i]
+			}
+		}
+		if found == nil {
+			p.Actions = append(p.Actions, Action{Action: Create, Type: TypeProject, Name: want.Name, project: want})
+			continue
+		}
+		matched[found.ID] = true
+		p.projectIDs[want.Name] = found.ID
+
+		var changes []Change
+		if want.Color != "" && want.Color != found.Color {
+			changes = append(changes, Change{"color", string(found.Color), string(want.Color)})
+		}
+		if want.ViewStyle != "" && want.ViewStyle != found.ViewStyle {
+			changes = append(changes, Change{"view_style", string(found.ViewStyle), string(want.ViewStyle)})
+		}
+		if want.Favorite != nil && *want.Favorite != found.IsFavorite {
+			changes = append(changes, Change{"favorite", strconv.FormatBool(found.IsFavorite), strconv.FormatBool(*want.Favorite)})
+		}
+		if old := parentName(*found); old != want.Parent {
+			changes = append(changes, Change{"parent", old, want.Parent})
+		}
+		if len(changes) > 0 {
+			p.Actions = append(p.Actions, Action{Action: Update, Type: TypeProject, Name: want.Name, ID: found.ID, Changes: changes, project: want})
+		}
+	}
+	return matched
+}
+
+// sortProjects returns the projects of a spec with every parent before its children,
+// otherwise keeping their order.
+func sortProjects(projects []ProjectSpec) []*ProjectSpec {
+	children := make(map[string][]*ProjectSpec)
+	for i := range projects {
+		children[projects[i].Parent] = append(children[projects[i].Parent], &projects[i])
+	}
+	var sorted []*ProjectSpec
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, project := range children[parent] {
+			sorted = append(sorted, project)
+			walk(project.Name)
+		}
+	}
+	walk("")
+	return sorted
+}
+
+// sections plans the sections of managed projects. Projects that do not exist yet get all
+// their sections.
+func (p *Plan) sections(client *todoist.TodoistClient, spec *Spec, opts Options) error {
+	var deletions []Action
+	for _, want := range sortProjects(spec.Projects) {
+		if want.Sections == nil {
+			continue
+		}
+		existing := make(map[string]bool)
+		if id, ok := p.projectIDs[want.Name]; ok {
+			sections, err := client.GetSections(id)
+			if err != nil {
+				return fmt.Errorf("plan: list sections of %q: %w", want.Name, err)
+			}
+			listed := make(map[string]bool, len(want.Sections))
+			for _, name := range want.Sections {
+				listed[name] = true
+			}
+			for _, s := range sections {
+				existing[s.Name] = true
+				if opts.Prune && !listed[s.Name] {
+					deletions = append(deletions, Action{Action: Delete, Type: TypeSection, Name: s.Name, Project: want.Name, ID: s.ID})
+				}
+			}
+		}
+		for _, name := range want.Sections {
+			if !existing[name] {
+				p.Actions = append(p.Actions, Action{Action: Create, Type: TypeSection, Name: name, Project: want.Name})
+			}
+		}
+	}
+	p.Actions = append(p.Actions, deletions...)
+	return nil
+}
+
+// pruneProjects deletes unmanaged projects, subprojects first.
+func (p *Plan) pruneProjects(projects []todoist.Project, matched map[string]bool) {
+	byID := make(map[string]todoist.Project, len(projects))
+	for _, project := range projects {
+		byID[project.ID] = project
+	}
+	depth := func(project todoist.Project) int {
+		n := 0
+		for project.ParentID != nil && n <= len(projects) {
+			project = byID[*project.ParentID]
+			n++
+		}
+		return n
+	}
+
+	var unmanaged []todoist.Project
+	for _, project := range projects {
+		if !matched[project.ID] && !project.IsInboxProject && !project.IsTeamInbox {
+			unmanaged = append(unmanaged, project)
+		}
+	}
+	sort.SliceStable(unmanaged, func(i, j int) bool { return depth(unmanaged[i]) > depth(unmanaged[j]) })
+	for _, project := range unmanaged {
+		p.Actions = append(p.Actions, Action{Action: Delete, Type: TypeProject, Name: project.Name, ID: project.ID})
+	}
+}
+
+func (p *Plan) pruneLabels(spec *Spec, labels []todoist.Label) {
+	listed := make(map[string]bool, len(spec.Labels))
+	for _, l := range spec.Labels {
+		listed[l.Name] = true
+	}
+	for _, l := range labels {
+		if !listed[l.Name] {
+			p.Actions = append(p.Actions, Action{Action: Delete, Type: TypeLabel, Name: l.Name, ID: l.ID})
+		}
+	}
+}
+
+// Empty reports whether the account already matches the spec.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// Count returns the number of actions of a type.
+func (p *Plan) Count(action ActionType) int {
+	n := 0
+	for _, a := range p.Actions {
+		if a.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+var actionSymbols = map[ActionType]string{Create: "+", Update: "~", Delete: "-"}
+
+// String returns the plan as one line per action followed by a summary.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+	var b strings.Builder
+	for _, a := range p.Actions {
+		name := strconv.Quote(a.Name)
+		if a.Project != "" {
+			name = strconv.Quote(a.Project) + " / " + name
+		}
+		fmt.Fprintf(&b, "%s %s %s", actionSymbols[a.Action], a.Type, name)
+		for i, c := range a.Changes {
+			sep := ", "
+			if i == 0 {
+				sep = ": "
+			}
+			fmt.Fprintf(&b, "%s%s %s -> %s", sep, c.Field, orNone(c.Old), orNone(c.New))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete.\n", p.Count(Create), p.Count(Update), p.Count(Delete))
+	return b.String()
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
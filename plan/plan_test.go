@@ -0,0 +1,149 @@
+package plan_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/plan"
+	"github.com/felixschmelzer/todoist-go/todoisttest"
+)
+
+const testSpec = `
+labels:
+  - name: urgent
+    color: red
+    favorite: true
+projects:
+  - name: Frontend
+    parent: Engineering
+    sections: [Ideas]
+  - name: Engineering
+    color: blue
+    view_style: board
+    favorite: true
+    sections: [Backlog, Doing, Done]
+`
+
+// seed fills an account with records that the spec partly matches.
+func seed(t *testing.T, client *todoist.TodoistClient) {
+	t.Helper()
+
+	engineering, err := client.CreateProject(todoist.ProjectParams{Name: "Engineering", Color: todoist.ColorGreen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Doing", "Misc"} {
+		if _, err := client.CreateSection(todoist.SectionParams{ProjectID: engineering.ID, Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Frontend exists, but at the top level.
+	if _, err := client.CreateProject(todoist.ProjectParams{Name: "Frontend"}); err != nil {
+		t.Fatal(err)
+	}
+	old, err := client.CreateProject(todoist.ProjectParams{Name: "Old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateProject(todoist.ProjectParams{Name: "Older", ParentID: old.ID}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"urgent", "stale"} {
+		if _, err := client.CreateLabel(todoist.LabelParams{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestApplyConverges(t *testing.T) {
+	tests := []struct {
+		name         string
+		prune        bool
+		wantProjects []string
+		wantLabels   []string
+		wantSections []string
+	}{
+		{"keep unmanaged", false, []string{"Engineering", "Frontend", "Inbox", "Old", "Older"}, []string{"stale", "urgent"}, []string{"Backlog", "Doing", "Done", "Ideas", "Misc"}},
+		{"prune", true, []string{"Engineering", "Frontend", "Inbox"}, []string{"urgent"}, []string{"Backlog", "Doing", "Done", "Ideas"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := todoisttest.NewServer("token")
+			defer server.Close()
+			client := server.Client()
+			seed(t, client)
+
+			spec, err := plan.Load(strings.NewReader(testSpec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := plan.Options{Prune: tt.prune}
+
+			p, err := plan.Compute(client, spec, opts)
+			if err != nil {
+				t.Fatalf("Compute: %v", err)
+			}
+			if p.Empty() {
+				t.Fatal("plan for a diverged account is empty")
+			}
+			if err := plan.Apply(client, p); err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+
+			p, err = plan.Compute(client, spec, opts)
+			if err != nil {
+				t.Fatalf("Compute after Apply: %v", err)
+			}
+			if !p.Empty() {
+				t.Errorf("plan after Apply is not empty:\n%s", p)
+			}
+
+			projects := make(map[string]todoist.Project)
+			var projectNames []string
+			for _, project := range server.Projects() {
+				projects[project.Name] = project
+				projectNames = append(projectNames, project.Name)
+			}
+			var labelNames []string
+			for _, label := range server.Labels() {
+				labelNames = append(labelNames, label.Name)
+			}
+			var sectionNames []string
+			for _, section := range server.Sections() {
+				sectionNames = append(sectionNames, section.Name)
+			}
+			assertNames(t, "projects", projectNames, tt.wantProjects)
+			assertNames(t, "labels", labelNames, tt.wantLabels)
+			assertNames(t, "sections", sectionNames, tt.wantSections)
+
+			engineering, frontend := projects["Engineering"], projects["Frontend"]
+			if engineering.Color != todoist.ColorBlue || engineering.ViewStyle != todoist.ViewStyleBoard || !engineering.IsFavorite {
+				t.Errorf("Engineering = %+v, want blue, board and favorite", engineering)
+			}
+			if frontend.ParentID == nil || *frontend.ParentID != engineering.ID {
+				t.Errorf("Frontend parent = %v, want %s", frontend.ParentID, engineering.ID)
+			}
+		})
+	}
+}
+
+// assertNames compares names regardless of order.
+func assertNames(t *testing.T, kind string, got, want []string) {
+	t.Helper()
+
+	counts := make(map[string]int)
+	for _, name := range got {
+		counts[name]++
+	}
+	for _, name := range want {
+		counts[name]--
+	}
+	for _, n := range counts {
+		if n != 0 {
+			t.Errorf("%s = %v, want %v", kind, got, want)
+			return
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package plan keeps the projects, sections and labels of an account in the state described by a
+// spec file, so a standard setup can live in version control.
+//
+// A spec is written in YAML or JSON:
+//
+//	labels:
+//	  - name: urgent
+//	    color: red
+//	projects:
+//	  - name: Engineering
+//	    color: blue
+//	    view_style: board
+//	    favorite: true
+//	    sections: [Backlog, Doing, Done]
+//	  - name: Frontend
+//	    parent: Engineering
+//
+// Projects and labels are matched by name. Fields left out of the spec are not managed: a project
+// without sections keeps whatever sections it has, and one without a color keeps its color.
+// Compute diffs a spec against an account and Apply carries out the resulting plan; applying a
+// plan and computing it again yields an empty plan.
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixschmelzer/todoist-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the desired state of an account.
+type Spec struct {
+	Projects []ProjectSpec `yaml:"projects,omitempty" json:"projects,omitempty"`
+	Labels   []LabelSpec   `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// ProjectSpec is the desired state of a project.
+type ProjectSpec struct {
+	Name      string            `yaml:"name" json:"name"`
+	Color     todoist.Color     `yaml:"color,omitempty" json:"color,omitempty"`
+	ViewStyle todoist.ViewStyle `yaml:"view_style,omitempty" json:"view_style,omitempty"`
+	// Parent is the name of the parent project, empty for a top-level project.
+	Parent   string `yaml:"parent,omitempty" json:"parent,omitempty"`
+	Favorite *bool  `yaml:"favorite,omitempty" json:"favorite,omitempty"`
+	// Sections are the names of the project's sections. Missing ones are created, but the order
+	// of existing sections is not changed. Nil leaves the sections unmanaged.
+	Sections []string `yaml:"sections,omitempty" json:"sections,omitempty"`
+}
+
+// LabelSpec is the desired state of a personal label.
+type LabelSpec struct {
+	Name     string        `yaml:"name" json:"name"`
+	Color    todoist.Color `yaml:"color,omitempty" json:"color,omitempty"`
+	Favorite *bool         `yaml:"favorite,omitempty" json:"favorite,omitempty"`
+}
+
+// Load reads a spec in YAML or JSON and validates it. Unknown fields are rejected, so typos
+// do not go unnoticed.
+func Load(r io.Reader) (*Spec, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("plan: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that names are set and unique, that parents exist and do not form cycles,
+// and that colors and view styles are known.
+func (s *Spec) Validate() error {
+	var errs todoist.ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, todoist.ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	parents := make(map[string]string, len(s.Projects))
+	for i, p := range s.Projects {
+		field := fmt.Sprintf("projects[%d]", i)
+		switch _, dup := parents[p.Name]; {
+		case strings.TrimSpace(p.Name) == "":
+			add(field, "name is required")
+		case dup:
+			add(field, "duplicate project %q", p.Name)
+		default:
+			parents[p.Name] = p.Parent
+		}
+		if p.Color != "" && !p.Color.IsValid() {
+			add(field, "unknown color %q", p.Color)
+		}
+		if p.ViewStyle != "" && p.ViewStyle != todoist.ViewStyleList && p.ViewStyle != todoist.ViewStyleBoard {
+			add(field, "view_style must be %q or %q", todoist.ViewStyleList, todoist.ViewStyleBoard)
+		}
+		sections := make(map[string]bool, len(p.Sections))
+		for _, name := range p.Sections {
+			switch {
+			case strings.TrimSpace(name) == "":
+				add(field, "section name is required")
+			case sections[name]:
+				add(field, "duplicate section %q", name)
+			}
+			sections[name] = true
+		}
+	}
+
+	for i, p := range s.Projects {
+		if p.Parent == "" {
+			continue
+		}
+		field := fmt.Sprintf("projects[%d]", i)
+		if _, ok := parents[p.Parent]; !ok {
+			add(field, "parent %q is not part of the spec", p.Parent)
+			continue
+		}
+		for name, seen := p.Parent, 0; name != "" && seen <= len(parents); name, seen = parents[name], seen+1 {
+			if name == p.Name {
+				add(field, "project %q is its own ancestor", p.Name)
+				break
+			}
+		}
+	}
+
+	labels := make(map[string]bool, len(s.Labels))
+	for i, l := range s.Labels {
+		field := fmt.Sprintf("labels[%d]", i)
+		switch {
+		case strings.TrimSpace(l.Name) == "":
+			add(field, "name is required")
+		case labels[l.Name]:
+			add(field, "duplicate label %q", l.Name)
+		}
+		labels[l.Name] = true
+		if l.Color != "" && !l.Color.IsValid() {
+			add(field, "unknown color %q", l.Color)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Priority is a task priority as used by the API, where 4 is the most urgent.
+// The Todoist apps show the same values inverted, as P1 (urgent) to P4 (normal).
+type Priority int
+
+const (
+	PriorityP1 Priority = 4
+	PriorityP2 Priority = 3
+	PriorityP3 Priority = 2
+	PriorityP4 Priority = 1
+)
+
+// IsValid reports whether p is one of the four priorities known to the API.
+func (p Priority) IsValid() bool {
+	return p >= PriorityP4 && p <= PriorityP1
+}
+
+// UI returns the number shown in the Todoist apps, so PriorityP1 returns 1.
+func (p Priority) UI() int {
+	if !p.IsValid() {
+		return 0
+	}
+	return 5 - int(p)
+}
+
+// PriorityFromUI converts a priority number as shown in the Todoist apps (1 = P1) to a Priority.
+func PriorityFromUI(n int) (Priority, error) {
+	if n < 1 || n > 4 {
+		return 0, fmt.Errorf("invalid UI priority %d, must be between 1 and 4", n)
+	}
+	return Priority(5 - n), nil
+}
+
+// ParsePriority parses a UI label such as "P1" or "p1". Bare numbers are rejected, as "1" would
+// mean P1 in the apps but P4 in the API.
+func ParsePriority(s string) (Priority, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 2 || (s[0] != 'p' && s[0] != 'P') || s[1] < '1' || s[1] > '4' {
+		return 0, fmt.Errorf("invalid priority %q, want P1 to P4", s)
+	}
+	return PriorityFromUI(int(s[1] - '0'))
+}
+
+// String returns the UI label of the priority, e.g. "P1" for PriorityP1.
+func (p Priority) String() string {
+	if !p.IsValid() {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return "P" + strconv.Itoa(p.UI())
+}
+
+// MarshalText encodes the priority as its UI label, such as "P1", and an unset priority as
+// empty text. Only JSON uses the API number, see MarshalJSON.
+func (p Priority) MarshalText() ([]byte, error) {
+	if p == 0 {
+		return []byte{}, nil
+	}
+	if !p.IsValid() {
+		return nil, fmt.Errorf("invalid priority %d", int(p))
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText accepts the labels accepted by ParsePriority, and empty text for an unset priority.
+func (p *Priority) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*p = 0
+		return nil
+	}
+	parsed, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalJSON encodes the priority as the API number, keeping request bodies in the API convention.
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(p))), nil
+}
+
+// UnmarshalJSON decodes an API number, or a quoted label accepted by UnmarshalText.
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return p.UnmarshalText([]byte(s))
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*p = Priority(n)
+	return nil
+}
+
+// CompareTaskUrgency orders tasks by urgency: higher priority first, then earlier due dates, then the project order.
+// It returns a negative number when a is more urgent than b.
+func CompareTaskUrgency(a, b Task) int {
+	if a.Priority != b.Priority {
+		return int(b.Priority) - int(a.Priority)
+	}
+
+	aDue, aOK := dueSortKey(a.Due)
+	bDue, bOK := dueSortKey(b.Due)
+	switch {
+	case !aOK && bOK:
+		return 1
+	case aOK && !bOK:
+		return -1
+	case aOK && !aDue.Equal(bDue):
+		return aDue.Compare(bDue)
+	}
+
+	return a.Order - b.Order
+}
+
+// SortTasksByUrgency sorts tasks in place from most to least urgent, see CompareTaskUrgency.
+func SortTasksByUrgency(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return CompareTaskUrgency(tasks[i], tasks[j]) < 0
+	})
+}
+
+// SortTasksByPriority sorts tasks in place from P1 to P4, keeping the existing order within a priority.
+func SortTasksByPriority(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Priority > tasks[j].Priority
+	})
+}
+
+// dueSortKey returns the time a task is due, with dates at midnight and times without a timezone
+// in the local one, and false if the task has no readable due date.
+func dueSortKey(due *TaskDue) (time.Time, bool) {
+	if due == nil {
+		return time.Time{}, false
+	}
+	if due.Datetime != "" {
+		if t, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+			return t, true
+		}
+		if t, err := time.ParseInLocation("2006-01-02T15:04:05", due.Datetime, time.Local); err == nil {
+			return t, true
+		}
+	}
+	t, err := time.ParseInLocation(time.DateOnly, due.Date, time.Local)
+	return t, err == nil
+}
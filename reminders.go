@@ -0,0 +1,261 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ReminderType is the kind of a reminder.
+type ReminderType string
+
+const (
+	ReminderRelative ReminderType = "relative" // A number of minutes before the task is due
+	ReminderAbsolute ReminderType = "absolute" // At a date and time
+	ReminderLocation ReminderType = "location" // When arriving at or leaving a place
+)
+
+// IsValid reports whether t is one of the reminder types supported by Todoist.
+func (t ReminderType) IsValid() bool {
+	switch t {
+	case ReminderRelative, ReminderAbsolute, ReminderLocation:
+		return true
+	}
+	return false
+}
+
+// LocationTrigger tells whether a location reminder fires when arriving at or leaving a place.
+type LocationTrigger string
+
+const (
+	TriggerOnEnter LocationTrigger = "on_enter"
+	TriggerOnLeave LocationTrigger = "on_leave"
+)
+
+// Reminder is a notification of a task. Reminders are only available through the Sync API.
+type Reminder struct {
+	ID        string       `json:"id"`
+	TaskID    string       `json:"item_id"`
+	Type      ReminderType `json:"type"`
+	NotifyUID string       `json:"notify_uid,omitempty"` // The user notified, the task owner by default
+	// MinuteOffset is how long before the task is due a relative reminder fires.
+	MinuteOffset int `json:"minute_offset,omitempty"`
+	// Due is when an absolute reminder fires. Its Date holds the date and time, such as "2026-10-20T09:00:00Z".
+	Due *TaskDue `json:"due,omitempty"`
+	// Name, Latitude, Longitude, Trigger and Radius (in meters) describe the place of a location reminder.
+	Name      string          `json:"name,omitempty"`
+	Latitude  string          `json:"loc_lat,omitempty"`
+	Longitude string          `json:"loc_long,omitempty"`
+	Trigger   LocationTrigger `json:"loc_trigger,omitempty"`
+	Radius    int             `json:"radius,omitempty"`
+	IsDeleted bool            `json:"is_deleted,omitempty"`
+}
+
+// ReminderParams defines the parameters for adding and updating a reminder. Only the fields of the
+// reminder's type may be set. MinuteOffset is a pointer so that updates can set it to zero.
+type ReminderParams struct {
+	TaskID    string       `json:"item_id,omitempty"`
+	Type      ReminderType `json:"type,omitempty"`
+	NotifyUID string       `json:"notify_uid,omitempty"`
+	// MinuteOffset is for relative reminders. Zero reminds at the time the task is due, which is
+	// also the offset of a new relative reminder when it is nil.
+	MinuteOffset *int `json:"minute_offset,omitempty"`
+	// DueDatetime is for absolute reminders, as an RFC 3339 date and time.
+	DueDatetime string `json:"-"`
+	// The remaining fields are for location reminders.
+	Name      string          `json:"name,omitempty"`
+	Latitude  string          `json:"loc_lat,omitempty"`
+	Longitude string          `json:"loc_long,omitempty"`
+	Trigger   LocationTrigger `json:"loc_trigger,omitempty"`
+	Radius    int             `json:"radius,omitempty"`
+}
+
+// args returns the arguments of a reminder_add or reminder_update command.
+func (p ReminderParams) args(id string) interface{} {
+	type params ReminderParams
+	args := struct {
+		ID  string `json:"id,omitempty"`
+		Due *struct {
+			Date string `json:"date"`
+		} `json:"due,omitempty"`
+		params
+	}{ID: id, params: params(p)}
+
+	if id == "" && p.Type == ReminderRelative && p.MinuteOffset == nil {
+		args.MinuteOffset = new(int)
+	}
+	if p.DueDatetime != "" {
+		at, _ := time.Parse(time.RFC3339, p.DueDatetime)
+		args.Due = &struct {
+			Date string `json:"date"`
+		}{at.UTC().Format("2006-01-02T15:04:05Z")}
+	}
+	return args
+}
+
+// GetReminders fetches the reminders of a task, or of all tasks when taskID is empty.
+func (c *TodoistClient) GetReminders(taskID string) ([]Reminder, error) {
+	return c.GetRemindersContext(context.Background(), taskID)
+}
+
+// GetRemindersContext is like GetReminders but uses ctx for the request.
+func (c *TodoistClient) GetRemindersContext(ctx context.Context, taskID string) ([]Reminder, error) {
+	resp, err := c.SyncContext(ctx, "", ResourceReminders)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]Reminder, 0, len(resp.Reminders))
+	for _, r := range resp.Reminders {
+		if !r.IsDeleted && (taskID == "" || r.TaskID == taskID) {
+			reminders = append(reminders, r)
+		}
+	}
+	return reminders, nil
+}
+
+// GetReminder fetches a reminder by its ID. A missing reminder is reported as an APIError
+// with status 404, like the other resources.
+func (c *TodoistClient) GetReminder(id string) (*Reminder, error) {
+	return c.GetReminderContext(context.Background(), id)
+}
+
+// GetReminderContext is like GetReminder but uses ctx for the request.
+func (c *TodoistClient) GetReminderContext(ctx context.Context, id string) (*Reminder, error) {
+	reminders, err := c.GetRemindersContext(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range reminders {
+		if reminders[i].ID == id {
+			return &reminders[i], nil
+		}
+	}
+	return nil, &APIError{StatusCode: http.StatusNotFound, Message: "reminder not found", action: "get reminder"}
+}
+
+// AddReminder adds a reminder to a task. Relative reminders require the task to be due at a time.
+func (c *TodoistClient) AddReminder(params ReminderParams) (*Reminder, error) {
+	return c.AddReminderContext(context.Background(), params)
+}
+
+// AddReminderContext is like AddReminder but uses ctx for the requests.
+func (c *TodoistClient) AddReminderContext(ctx context.Context, params ReminderParams) (*Reminder, error) {
+	if err := params.ValidateCreate(); err != nil {
+		return nil, err
+	}
+
+	cmd := NewCommand("reminder_add", params.args(""))
+	cmd.TempID = newUUID()
+	result, err := c.ExecuteCommandsContext(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := result.TempIDMapping[cmd.TempID]
+	if !ok {
+		return nil, fmt.Errorf("no ID returned for the new reminder")
+	}
+	return c.GetReminderContext(ctx, id)
+}
+
+// UpdateReminder changes the fields of a reminder that are set in params. The task of a reminder
+// cannot be changed.
+func (c *TodoistClient) UpdateReminder(id string, params ReminderParams) (*Reminder, error) {
+	return c.UpdateReminderContext(context.Background(), id, params)
+}
+
+// UpdateReminderContext is like UpdateReminder but uses ctx for the requests.
+func (c *TodoistClient) UpdateReminderContext(ctx context.Context, id string, params ReminderParams) (*Reminder, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if params.TaskID != "" {
+		return nil, ValidationErrors{{Field: "item_id", Message: "cannot be changed"}}
+	}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("reminder_update", params.args(id))); err != nil {
+		return nil, err
+	}
+	return c.GetReminderContext(ctx, id)
+}
+
+// DeleteReminder deletes a reminder.
+func (c *TodoistClient) DeleteReminder(id string) (bool, error) {
+	return c.DeleteReminderContext(context.Background(), id)
+}
+
+// DeleteReminderContext is like DeleteReminder but uses ctx for the request.
+func (c *TodoistClient) DeleteReminderContext(ctx context.Context, id string) (bool, error) {
+	args := struct {
+		ID string `json:"id"`
+	}{id}
+
+	if _, err := c.ExecuteCommandsContext(ctx, NewCommand("reminder_delete", args)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateTaskWithReminders creates a task and then adds reminders to it. The task is created first,
+// and the reminders are then added in one batch of Sync API commands. The TaskID of the reminders
+// is set to the new task.
+//
+// All parameters are checked before the task is created. Relative reminders require the task to be
+// due at a time, given as DueDatetime or as a DueString such as "tomorrow 9am". If adding the
+// reminders fails, the created task is returned together with the error, so that it is not created again.
+func (c *TodoistClient) CreateTaskWithReminders(params TaskParams, reminders ...ReminderParams) (*Task, []Reminder, error) {
+	return c.CreateTaskWithRemindersContext(context.Background(), params, reminders...)
+}
+
+// CreateTaskWithRemindersContext is like CreateTaskWithReminders but uses ctx for the requests.
+func (c *TodoistClient) CreateTaskWithRemindersContext(ctx context.Context, params TaskParams, reminders ...ReminderParams) (*Task, []Reminder, error) {
+	errs := params.validateNew()
+	for i, r := range reminders {
+		for _, e := range r.validateNew() {
+			errs.add(fmt.Sprintf("reminders[%d].%s", i, e.Field), e.Message)
+		}
+		if r.Type == ReminderRelative && params.DueDatetime == "" && params.DueString == "" {
+			errs.add(fmt.Sprintf("reminders[%d].type", i), "relative reminders require a due_datetime or due_string")
+		}
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+
+	task, err := c.CreateTaskContext(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(reminders) == 0 {
+		return task, nil, nil
+	}
+
+	cmds := make([]Command, len(reminders))
+	for i, r := range reminders {
+		r.TaskID = task.ID
+		cmds[i] = NewCommand("reminder_add", r.args(""))
+		cmds[i].TempID = newUUID()
+	}
+	result, err := c.ExecuteCommandsContext(ctx, cmds...)
+	if err != nil {
+		return task, nil, err
+	}
+
+	all, err := c.GetRemindersContext(ctx, task.ID)
+	if err != nil {
+		return task, nil, err
+	}
+	byID := make(map[string]Reminder, len(all))
+	for _, r := range all {
+		byID[r.ID] = r
+	}
+	created := make([]Reminder, 0, len(cmds))
+	for _, cmd := range cmds {
+		if r, ok := byID[result.TempIDMapping[cmd.TempID]]; ok {
+			created = append(created, r)
+		}
+	}
+	return task, created, nil
+}
@@ -0,0 +1,220 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ResourceType names a kind of data that can be requested from the Sync API.
+type ResourceType string
+
+const (
+	ResourceProjects     ResourceType = "projects"
+	ResourceSections     ResourceType = "sections"
+	ResourceItems        ResourceType = "items"
+	ResourceNotes        ResourceType = "notes"
+	ResourceProjectNotes ResourceType = "project_notes"
+	ResourceLabels       ResourceType = "labels"
+	ResourceFilters      ResourceType = "filters"
+	ResourceReminders    ResourceType = "reminders"
+)
+
+// SyncResponse is the result of a read request to the Sync API.
+// On an incremental sync only the records that changed since the given sync token are included.
+type SyncResponse struct {
+	SyncToken    string        `json:"sync_token"`
+	FullSync     bool          `json:"full_sync"`
+	Projects     []SyncProject `json:"projects,omitempty"`
+	Sections     []SyncSection `json:"sections,omitempty"`
+	Items        []SyncItem    `json:"items,omitempty"`
+	Notes        []SyncNote    `json:"notes,omitempty"`
+	ProjectNotes []SyncNote    `json:"project_notes,omitempty"`
+	Labels       []SyncLabel   `json:"labels,omitempty"`
+	Filters      []Filter      `json:"filters,omitempty"`
+	Reminders    []Reminder    `json:"reminders,omitempty"`
+}
+
+// SyncProject represents a project as returned by the Sync API.
+type SyncProject struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Color        Color     `json:"color"`
+	ParentID     *string   `json:"parent_id"`
+	ChildOrder   int       `json:"child_order"`
+	Collapsed    bool      `json:"collapsed"`
+	Shared       bool      `json:"shared"`
+	IsDeleted    bool      `json:"is_deleted"`
+	IsArchived   bool      `json:"is_archived"`
+	IsFavorite   bool      `json:"is_favorite"`
+	ViewStyle    ViewStyle `json:"view_style"`
+	InboxProject bool      `json:"inbox_project,omitempty"`
+	TeamInbox    bool      `json:"team_inbox,omitempty"`
+}
+
+// ToProject converts the Sync API project to the REST model.
+func (p SyncProject) ToProject() Project {
+	return Project{
+		ID:             p.ID,
+		Name:           p.Name,
+		Order:          p.ChildOrder,
+		Color:          p.Color,
+		IsShared:       p.Shared,
+		IsFavorite:     p.IsFavorite,
+		IsInboxProject: p.InboxProject,
+		IsTeamInbox:    p.TeamInbox,
+		ViewStyle:      p.ViewStyle,
+		URL:            "https://todoist.com/showProject?id=" + p.ID,
+		ParentID:       p.ParentID,
+	}
+}
+
+// SyncSection represents a section as returned by the Sync API.
+type SyncSection struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ProjectID    string `json:"project_id"`
+	SectionOrder int    `json:"section_order"`
+	Collapsed    bool   `json:"collapsed"`
+	IsDeleted    bool   `json:"is_deleted"`
+	IsArchived   bool   `json:"is_archived"`
+}
+
+// ToSection converts the Sync API section to the REST model.
+func (s SyncSection) ToSection() Section {
+	return Section{
+		ID:        s.ID,
+		ProjectID: s.ProjectID,
+		Order:     s.SectionOrder,
+		Name:      s.Name,
+	}
+}
+
+// SyncItem represents a task as returned by the Sync API.
+type SyncItem struct {
+	ID             string        `json:"id"`
+	ProjectID      string        `json:"project_id"`
+	SectionID      string        `json:"section_id"`
+	ParentID       string        `json:"parent_id"`
+	Content        string        `json:"content"`
+	Description    string        `json:"description"`
+	Priority       Priority      `json:"priority"`
+	Labels         []string      `json:"labels"`
+	ChildOrder     int           `json:"child_order"`
+	Due            *TaskDue      `json:"due"`
+	Duration       *TaskDuration `json:"duration"`
+	AddedByUID     string        `json:"added_by_uid"`
+	AssignedByUID  string        `json:"assigned_by_uid"`
+	ResponsibleUID string        `json:"responsible_uid"`
+	Checked        bool          `json:"checked"`
+	IsDeleted      bool          `json:"is_deleted"`
+	AddedAt        string        `json:"added_at"`
+	CompletedAt    string        `json:"completed_at"`
+}
+
+// ToTask converts the Sync API item to the REST model.
+func (i SyncItem) ToTask() Task {
+	return Task{
+		ID:          i.ID,
+		ProjectID:   i.ProjectID,
+		SectionID:   i.SectionID,
+		ParentID:    i.ParentID,
+		Content:     i.Content,
+		Description: i.Description,
+		IsCompleted: i.Checked,
+		Labels:      i.Labels,
+		Order:       i.ChildOrder,
+		Priority:    i.Priority,
+		AssigneeID:  i.ResponsibleUID,
+		AssignerID:  i.AssignedByUID,
+		Due:         i.Due,
+		Duration:    i.Duration,
+		URL:         "https://todoist.com/showTask?id=" + i.ID,
+	}
+}
+
+// SyncNote represents a task or project comment as returned by the Sync API.
+type SyncNote struct {
+	ID             string      `json:"id"`
+	ItemID         string      `json:"item_id,omitempty"`
+	ProjectID      string      `json:"project_id"`
+	Content        string      `json:"content"`
+	PostedAt       string      `json:"posted_at"`
+	FileAttachment *Attachment `json:"file_attachment"`
+	IsDeleted      bool        `json:"is_deleted"`
+}
+
+// ToComment converts the Sync API note to the REST model.
+func (n SyncNote) ToComment() Comment {
+	comment := Comment{
+		ID:         n.ID,
+		TaskID:     n.ItemID,
+		Content:    n.Content,
+		PostedAt:   n.PostedAt,
+		Attachment: n.FileAttachment,
+	}
+	if n.ItemID == "" {
+		comment.ProjectID = n.ProjectID
+	}
+	return comment
+}
+
+// SyncLabel represents a personal label as returned by the Sync API.
+type SyncLabel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      Color  `json:"color"`
+	ItemOrder  int    `json:"item_order"`
+	IsDeleted  bool   `json:"is_deleted"`
+	IsFavorite bool   `json:"is_favorite"`
+}
+
+// ToLabel converts the Sync API label to the REST model.
+func (l SyncLabel) ToLabel() Label {
+	return Label{
+		ID:         l.ID,
+		Name:       l.Name,
+		Color:      l.Color,
+		Order:      l.ItemOrder,
+		IsFavorite: l.IsFavorite,
+	}
+}
+
+// Sync reads the given resource types from the Sync API.
+// With an empty sync token (or "*") everything is returned, otherwise only what changed since the token was issued.
+func (c *TodoistClient) Sync(syncToken string, resourceTypes ...ResourceType) (*SyncResponse, error) {
+	return c.SyncContext(context.Background(), syncToken, resourceTypes...)
+}
+
+// SyncContext is like Sync but uses ctx for the request.
+func (c *TodoistClient) SyncContext(ctx context.Context, syncToken string, resourceTypes ...ResourceType) (*SyncResponse, error) {
+	if syncToken == "" {
+		syncToken = "*"
+	}
+	if len(resourceTypes) == 0 {
+		return nil, errors.New("no resource types given")
+	}
+
+	types, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("sync_token", syncToken)
+	form.Set("resource_types", string(types))
+
+	resp, err := sendForm(ctx, c.HTTPClient, fmt.Sprintf("%s/sync", c.SyncBaseURL), c.tokenSource(), form)
+	if err != nil {
+		return nil, err
+	}
+
+	var syncResp SyncResponse
+	if err := parseResponse(resp, &syncResp); err != nil {
+		return nil, err
+	}
+
+	return &syncResp, nil
+}
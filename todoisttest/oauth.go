@@ -0,0 +1,176 @@
+// Package todoisttest provides in-memory stand-ins for Todoist servers, for use in tests.
+package todoisttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// OAuthServer is a stand-in for the Todoist OAuth endpoints.
+// Every authorization request is granted immediately, as if the user had approved it.
+type OAuthServer struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // Where /oauth/authorize redirects to; without it the code is returned as JSON
+
+	mu     sync.Mutex
+	codes  map[string][]todoist.Scope
+	tokens map[string][]todoist.Scope
+}
+
+// NewOAuthServer starts an OAuth server for an app with the given credentials. Call Close when done.
+func NewOAuthServer(clientID, clientSecret string) *OAuthServer {
+	s := &OAuthServer{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		codes:        make(map[string][]todoist.Scope),
+		tokens:       make(map[string][]todoist.Scope),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", s.handleAuthorize)
+	mux.HandleFunc("/oauth/access_token", s.handleAccessToken)
+	mux.HandleFunc("/access_tokens/revoke", s.handleRevoke)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Config returns an OAuthConfig for the app that points at the server.
+func (s *OAuthServer) Config(scopes ...todoist.Scope) *todoist.OAuthConfig {
+	return &todoist.OAuthConfig{
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		Scopes:       scopes,
+		AuthorizeURL: s.URL + "/oauth/authorize",
+		TokenURL:     s.URL + "/oauth/access_token",
+		RevokeURL:    s.URL + "/access_tokens/revoke",
+		HTTPClient:   s.Client(),
+	}
+}
+
+// IssueCode creates an authorization code for the given scopes without going through /oauth/authorize.
+func (s *OAuthServer) IssueCode(scopes ...todoist.Scope) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := randomString()
+	s.codes[code] = scopes
+	return code
+}
+
+// ValidToken reports whether token was issued by the server and has not been revoked.
+func (s *OAuthServer) ValidToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.tokens[token]
+	return ok
+}
+
+// Scopes returns the scopes granted to a token.
+func (s *OAuthServer) Scopes(token string) []todoist.Scope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokens[token]
+}
+
+func (s *OAuthServer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_application_status"})
+		return
+	}
+
+	var scopes []todoist.Scope
+	for _, scope := range strings.Split(query.Get("scope"), ",") {
+		if scope != "" {
+			scopes = append(scopes, todoist.Scope(scope))
+		}
+	}
+	if len(scopes) == 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_scope"})
+		return
+	}
+
+	code := s.IssueCode(scopes...)
+	if s.RedirectURL == "" {
+		writeJSON(w, http.StatusOK, map[string]string{"code": code, "state": query.Get("state")})
+		return
+	}
+
+	redirect := url.Values{}
+	redirect.Set("code", code)
+	redirect.Set("state", query.Get("state"))
+	http.Redirect(w, r, s.RedirectURL+"?"+redirect.Encode(), http.StatusFound)
+}
+
+func (s *OAuthServer) handleAccessToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.FormValue("client_id") != s.ClientID || r.FormValue("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "bad_client_credentials"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := r.FormValue("code")
+	scopes, ok := s.codes[code]
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "bad_authorization_code"})
+		return
+	}
+	delete(s.codes, code)
+
+	token := randomString()
+	s.tokens[token] = scopes
+	writeJSON(w, http.StatusOK, map[string]string{"access_token": token, "token_type": "Bearer"})
+}
+
+func (s *OAuthServer) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.FormValue("client_id") != s.ClientID || r.FormValue("client_secret") != s.ClientSecret {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.tokens, r.FormValue("access_token"))
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// randomString returns a random hex string for codes, tokens and IDs.
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
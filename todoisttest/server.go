@@ -0,0 +1,997 @@
+package todoisttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/filter"
+)
+
+// Server is an in-memory stand-in for the Todoist REST API, plus the parts of the Sync API used by the client.
+// Data only lives as long as the server; start each test with a fresh one.
+type Server struct {
+	*httptest.Server
+
+	Token string // Required bearer token; any token is accepted if empty
+
+	mu        sync.Mutex
+	nextID    int
+	projects  []*todoist.Project
+	sections  []*todoist.Section
+	tasks     []*todoist.Task
+	comments  []*todoist.Comment
+	labels    []*todoist.Label
+	filters   []*todoist.Filter
+	reminders []*todoist.Reminder
+	closed    map[string]time.Time // Completion times of completed tasks
+	archived  map[string]bool      // IDs of archived projects
+	failures  []int
+	requests  []string
+}
+
+// NewServer starts an empty server accepting the given token. Call Close when done.
+// The account has an inbox project, like every Todoist account.
+func NewServer(token string) *Server {
+	s := &Server{Token: token, nextID: 1000, closed: make(map[string]time.Time), archived: make(map[string]bool)}
+	s.projects = append(s.projects, &todoist.Project{
+		ID:             s.newID(),
+		Name:           "Inbox",
+		Color:          todoist.ColorGrey,
+		IsInboxProject: true,
+		ViewStyle:      todoist.ViewStyleList,
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v2/projects", s.listProjects)
+	mux.HandleFunc("POST /rest/v2/projects", s.createProject)
+	mux.HandleFunc("GET /rest/v2/projects/{id}", s.getProject)
+	mux.HandleFunc("POST /rest/v2/projects/{id}", s.updateProject)
+	mux.HandleFunc("DELETE /rest/v2/projects/{id}", s.deleteProject)
+
+	mux.HandleFunc("GET /rest/v2/sections", s.listSections)
+	mux.HandleFunc("POST /rest/v2/sections", s.createSection)
+	mux.HandleFunc("GET /rest/v2/sections/{id}", s.getSection)
+	mux.HandleFunc("POST /rest/v2/sections/{id}", s.updateSection)
+	mux.HandleFunc("DELETE /rest/v2/sections/{id}", s.deleteSection)
+
+	mux.HandleFunc("GET /rest/v2/tasks", s.listTasks)
+	mux.HandleFunc("POST /rest/v2/tasks", s.createTask)
+	mux.HandleFunc("GET /rest/v2/tasks/{id}", s.getTask)
+	mux.HandleFunc("POST /rest/v2/tasks/{id}", s.updateTask)
+	mux.HandleFunc("POST /rest/v2/tasks/{id}/close", s.closeTask)
+	mux.HandleFunc("POST /rest/v2/tasks/{id}/reopen", s.reopenTask)
+	mux.HandleFunc("DELETE /rest/v2/tasks/{id}", s.deleteTask)
+
+	mux.HandleFunc("GET /rest/v2/comments", s.listComments)
+	mux.HandleFunc("POST /rest/v2/comments", s.createComment)
+	mux.HandleFunc("GET /rest/v2/comments/{id}", s.getComment)
+	mux.HandleFunc("POST /rest/v2/comments/{id}", s.updateComment)
+	mux.HandleFunc("DELETE /rest/v2/comments/{id}", s.deleteComment)
+
+	mux.HandleFunc("GET /rest/v2/labels", s.listLabels)
+	mux.HandleFunc("POST /rest/v2/labels", s.createLabel)
+	mux.HandleFunc("GET /rest/v2/labels/{id}", s.getLabel)
+	mux.HandleFunc("POST /rest/v2/labels/{id}", s.updateLabel)
+	mux.HandleFunc("DELETE /rest/v2/labels/{id}", s.deleteLabel)
+
+	mux.HandleFunc("POST /sync/v9/sync", s.handleSync)
+	mux.HandleFunc("POST /sync/v9/completed/get_all", s.handleCompleted)
+	mux.HandleFunc("POST /sync/v9/projects/get_archived", s.handleArchivedProjects)
+	mux.HandleFunc("POST /sync/v9/projects/get_data", s.handleProjectData)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Client returns a client that talks to the server.
+func (s *Server) Client() *todoist.TodoistClient {
+	client := todoist.NewTodoistClient(s.Token)
+	s.Configure(client)
+	return client
+}
+
+// Configure points an existing client, e.g. one with its own token source, at the server.
+func (s *Server) Configure(client *todoist.TodoistClient) {
+	client.BaseURL = s.URL + "/rest/v2"
+	client.SyncBaseURL = s.URL + "/sync/v9"
+	client.HTTPClient = s.Server.Client()
+}
+
+// FailNext makes the next requests fail with the given HTTP status codes, one status per request.
+func (s *Server) FailNext(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, statuses...)
+}
+
+// Requests returns the method and path of every request received so far, e.g. "POST /rest/v2/tasks".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// Projects returns a snapshot of all projects.
+func (s *Server) Projects() []todoist.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return snapshot(s.projects)
+}
+
+// Sections returns a snapshot of all sections.
+func (s *Server) Sections() []todoist.Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return snapshot(s.sections)
+}
+
+// Tasks returns a snapshot of all tasks, including completed ones.
+func (s *Server) Tasks() []todoist.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return snapshot(s.tasks)
+}
+
+// Comments returns a snapshot of all comments.
+func (s *Server) Comments() []todoist.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return snapshot(s.comments)
+}
+
+// Labels returns a snapshot of all personal labels.
+func (s *Server) Labels() []todoist.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return snapshot(s.labels)
+}
+
+// middleware checks the token, records requests and injects the failures queued with FailNext.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		var failure int
+		if len(s.failures) > 0 {
+			failure, s.failures = s.failures[0], s.failures[1:]
+		}
+		s.mu.Unlock()
+
+		if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+			http.Error(w, "Forbidden", http.StatusUnauthorized)
+			return
+		}
+		if failure != 0 {
+			http.Error(w, http.StatusText(failure), failure)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// snapshot copies the values behind a slice of pointers.
+func snapshot[T any](items []*T) []T {
+	values := make([]T, len(items))
+	for i, item := range items {
+		values[i] = *item
+	}
+	return values
+}
+
+// find returns the index of the item with the given ID, or -1.
+func find[T any](items []*T, id string, idOf func(*T) string) int {
+	for i, item := range items {
+		if idOf(item) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeWhere deletes the items for which remove returns true and returns the remaining ones.
+func removeWhere[T any](items []*T, remove func(*T) bool) []*T {
+	kept := items[:0]
+	for _, item := range items {
+		if !remove(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// decodeBody decodes a JSON request body into params and also returns the set of fields present,
+// so updates only touch what the client sent.
+func decodeBody(r *http.Request, params interface{}) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if r.Body == nil || r.ContentLength == 0 {
+		return fields, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(fields)
+	return fields, json.Unmarshal(data, params)
+}
+
+func badRequest(w http.ResponseWriter, format string, args ...interface{}) {
+	http.Error(w, fmt.Sprintf(format, args...), http.StatusBadRequest)
+}
+
+func notFound(w http.ResponseWriter) {
+	http.Error(w, "Not Found", http.StatusNotFound)
+}
+
+// Projects
+
+func (s *Server) projectIndex(id string) int {
+	return find(s.projects, id, func(p *todoist.Project) string { return p.ID })
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projects := []todoist.Project{}
+	for _, p := range s.projects {
+		if !s.archived[p.ID] {
+			projects = append(projects, *p)
+		}
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var params todoist.ProjectParams
+	if _, err := decodeBody(r, &params); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+	if params.Name == "" {
+		badRequest(w, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if params.ParentID != "" && s.projectIndex(params.ParentID) < 0 {
+		badRequest(w, "parent project not found")
+		return
+	}
+
+	project := &todoist.Project{
+		ID:         s.newID(),
+		Name:       params.Name,
+		Order:      len(s.projects),
+		Color:      params.Color,
+		IsFavorite: params.IsFavorite,
+		ViewStyle:  params.ViewStyle,
+	}
+	if project.Color == "" {
+		project.Color = todoist.ColorCharcoal
+	}
+	if project.ViewStyle == "" {
+		project.ViewStyle = todoist.ViewStyleList
+	}
+	if params.ParentID != "" {
+		parentID := params.ParentID
+		project.ParentID = &parentID
+	}
+	project.URL = "https://todoist.com/showProject?id=" + project.ID
+
+	s.projects = append(s.projects, project)
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.projectIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.projects[i])
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var params todoist.ProjectParams
+	fields, err := decodeBody(r, &params)
+	if err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.projectIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	project := s.projects[i]
+	applyProject(project, params, fields)
+	writeJSON(w, http.StatusOK, project)
+}
+
+// applyProject updates the fields of a project that are present in a request.
+func applyProject(project *todoist.Project, params todoist.ProjectParams, fields map[string]json.RawMessage) {
+	if _, ok := fields["name"]; ok {
+		project.Name = params.Name
+	}
+	if _, ok := fields["color"]; ok {
+		project.Color = params.Color
+	}
+	if _, ok := fields["is_favorite"]; ok {
+		project.IsFavorite = params.IsFavorite
+	}
+	if _, ok := fields["view_style"]; ok {
+		project.ViewStyle = params.ViewStyle
+	}
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	i := s.projectIndex(id)
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	if s.projects[i].IsInboxProject {
+		badRequest(w, "the inbox project cannot be deleted")
+		return
+	}
+
+	s.projects = removeWhere(s.projects, func(p *todoist.Project) bool {
+		return p.ID == id || (p.ParentID != nil && *p.ParentID == id)
+	})
+	s.sections = removeWhere(s.sections, func(sec *todoist.Section) bool { return sec.ProjectID == id })
+	s.tasks = removeWhere(s.tasks, func(t *todoist.Task) bool { return t.ProjectID == id })
+	s.comments = removeWhere(s.comments, func(c *todoist.Comment) bool { return c.ProjectID == id })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Sections
+
+func (s *Server) sectionIndex(id string) int {
+	return find(s.sections, id, func(sec *todoist.Section) string { return sec.ID })
+}
+
+func (s *Server) listSections(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sections := []todoist.Section{}
+	for _, sec := range s.sections {
+		if (projectID == "" && !s.archived[sec.ProjectID]) || sec.ProjectID == projectID {
+			sections = append(sections, *sec)
+		}
+	}
+	writeJSON(w, http.StatusOK, sections)
+}
+
+func (s *Server) createSection(w http.ResponseWriter, r *http.Request) {
+	var params todoist.SectionParams
+	if _, err := decodeBody(r, &params); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+	if params.Name == "" {
+		badRequest(w, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.projectIndex(params.ProjectID) < 0 {
+		badRequest(w, "project not found")
+		return
+	}
+
+	section := &todoist.Section{
+		ID:        s.newID(),
+		ProjectID: params.ProjectID,
+		Name:      params.Name,
+		Order:     params.Order,
+	}
+	if section.Order == 0 {
+		for _, sec := range s.sections {
+			if sec.ProjectID == section.ProjectID && sec.Order >= section.Order {
+				section.Order = sec.Order + 1
+			}
+		}
+	}
+
+	s.sections = append(s.sections, section)
+	writeJSON(w, http.StatusOK, section)
+}
+
+func (s *Server) getSection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.sectionIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.sections[i])
+}
+
+func (s *Server) updateSection(w http.ResponseWriter, r *http.Request) {
+	var params todoist.SectionParams
+	fields, err := decodeBody(r, &params)
+	if err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.sectionIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	if _, ok := fields["name"]; ok {
+		s.sections[i].Name = params.Name
+	}
+	writeJSON(w, http.StatusOK, s.sections[i])
+}
+
+func (s *Server) deleteSection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.sectionIndex(id) < 0 {
+		notFound(w)
+		return
+	}
+	s.sections = removeWhere(s.sections, func(sec *todoist.Section) bool { return sec.ID == id })
+	s.tasks = removeWhere(s.tasks, func(t *todoist.Task) bool { return t.SectionID == id })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Tasks
+
+func (s *Server) taskIndex(id string) int {
+	return find(s.tasks, id, func(t *todoist.Task) string { return t.ID })
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	projectID, sectionID, label := query.Get("project_id"), query.Get("section_id"), query.Get("label")
+	var f *filter.Filter
+	if q := query.Get("filter"); q != "" {
+		var err error
+		if f, err = filter.Parse(q); err != nil {
+			badRequest(w, "%v", err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Filter queries are evaluated locally, which covers the filters the filter package supports.
+	env := &filter.Env{Projects: snapshot(s.projects), Sections: snapshot(s.sections)}
+	tasks := []todoist.Task{}
+	for _, t := range s.tasks {
+		switch {
+		case t.IsCompleted, s.archived[t.ProjectID]:
+		case projectID != "" && t.ProjectID != projectID:
+		case sectionID != "" && t.SectionID != sectionID:
+		case label != "" && !contains(t.Labels, label):
+		case f != nil && !f.Match(*t, env):
+		default:
+			tasks = append(tasks, *t)
+		}
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var params todoist.TaskParams
+	fields, err := decodeBody(r, &params)
+	if err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+	if params.Content == "" {
+		badRequest(w, "content is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task := &todoist.Task{
+		ID:        s.newID(),
+		ProjectID: s.projects[0].ID,
+		Priority:  todoist.PriorityP4,
+		Labels:    []string{},
+	}
+	if msg := s.applyTask(task, params, fields); msg != "" {
+		badRequest(w, "%s", msg)
+		return
+	}
+	for _, t := range s.tasks {
+		if t.ProjectID == task.ProjectID && t.Order >= task.Order {
+			task.Order = t.Order + 1
+		}
+	}
+	task.URL = "https://todoist.com/showTask?id=" + task.ID
+
+	s.tasks = append(s.tasks, task)
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.taskIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.tasks[i])
+}
+
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	var params todoist.TaskParams
+	fields, err := decodeBody(r, &params)
+	if err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.taskIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+
+	// Like the real API, the location of a task cannot be changed by an update.
+	delete(fields, "project_id")
+	delete(fields, "section_id")
+	delete(fields, "parent_id")
+
+	task := *s.tasks[i]
+	if msg := s.applyTask(&task, params, fields); msg != "" {
+		badRequest(w, "%s", msg)
+		return
+	}
+	*s.tasks[i] = task
+	writeJSON(w, http.StatusOK, s.tasks[i])
+}
+
+// applyTask copies the fields present in a request onto a task and returns an error message if they are invalid.
+func (s *Server) applyTask(task *todoist.Task, params todoist.TaskParams, fields map[string]json.RawMessage) string {
+	has := func(name string) bool {
+		_, ok := fields[name]
+		return ok
+	}
+
+	if has("content") {
+		task.Content = params.Content
+	}
+	if has("description") {
+		task.Description = params.Description
+	}
+	if has("priority") {
+		if !params.Priority.IsValid() {
+			return "priority must be between 1 and 4"
+		}
+		task.Priority = params.Priority
+	}
+	if has("labels") {
+		task.Labels = append([]string{}, params.Labels...)
+	}
+	if has("assignee_id") {
+		task.AssigneeID = params.AssigneeID
+	}
+
+	if has("project_id") {
+		if s.projectIndex(params.ProjectID) < 0 {
+			return "project not found"
+		}
+		task.ProjectID = params.ProjectID
+	}
+	if has("section_id") {
+		i := s.sectionIndex(params.SectionID)
+		if i < 0 {
+			return "section not found"
+		}
+		task.SectionID = params.SectionID
+		task.ProjectID = s.sections[i].ProjectID
+	}
+	if has("parent_id") {
+		i := s.taskIndex(params.ParentID)
+		if i < 0 {
+			return "parent task not found"
+		}
+		task.ParentID = params.ParentID
+		task.ProjectID = s.tasks[i].ProjectID
+		task.SectionID = s.tasks[i].SectionID
+	}
+
+	switch {
+	case has("due_string") && params.DueString == "no date":
+		task.Due = nil
+	case has("due_string"):
+		task.Due = &todoist.TaskDue{String: params.DueString, Date: resolveDueString(params.DueString)}
+	case has("due_date"):
+		task.Due = &todoist.TaskDue{String: params.DueDate, Date: params.DueDate}
+	case has("due_datetime"):
+		due, err := time.Parse(time.RFC3339, params.DueDatetime)
+		if err != nil {
+			return "invalid due_datetime"
+		}
+		task.Due = &todoist.TaskDue{String: params.DueDatetime, Date: due.Format("2006-01-02"), Datetime: params.DueDatetime}
+	}
+
+	if has("duration") || has("duration_unit") {
+		if params.Duration == 0 {
+			task.Duration = nil
+		} else {
+			task.Duration = &todoist.TaskDuration{Amount: params.Duration, Unit: params.DurationUnit}
+		}
+	}
+
+	return ""
+}
+
+// resolveDueString understands the few natural language dates tests typically use.
+func resolveDueString(s string) string {
+	today := time.Now()
+	switch strings.ToLower(s) {
+	case "today":
+		return today.Format("2006-01-02")
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format("2006-01-02")
+	case "yesterday":
+		return today.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return s
+	}
+	return today.Format("2006-01-02")
+}
+
+func (s *Server) closeTask(w http.ResponseWriter, r *http.Request) {
+	s.setCompleted(w, r.PathValue("id"), true)
+}
+
+func (s *Server) reopenTask(w http.ResponseWriter, r *http.Request) {
+	s.setCompleted(w, r.PathValue("id"), false)
+}
+
+func (s *Server) setCompleted(w http.ResponseWriter, id string, completed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.taskIndex(id)
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	now := time.Now().UTC()
+	for _, t := range s.tasks {
+		if t.ID == id || (completed && t.ParentID == id) {
+			t.IsCompleted = completed
+			if completed {
+				s.closed[t.ID] = now
+			} else {
+				delete(s.closed, t.ID)
+			}
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.taskIndex(id) < 0 {
+		notFound(w)
+		return
+	}
+	s.tasks = removeWhere(s.tasks, func(t *todoist.Task) bool { return t.ID == id || t.ParentID == id })
+	s.comments = removeWhere(s.comments, func(c *todoist.Comment) bool { return c.TaskID == id })
+	s.reminders = removeWhere(s.reminders, func(r *todoist.Reminder) bool { return s.taskIndex(r.TaskID) < 0 })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Comments
+
+func (s *Server) commentIndex(id string) int {
+	return find(s.comments, id, func(c *todoist.Comment) string { return c.ID })
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	taskID, projectID := query.Get("task_id"), query.Get("project_id")
+	if (taskID == "") == (projectID == "") {
+		badRequest(w, "exactly one of task_id and project_id is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comments := []todoist.Comment{}
+	for _, c := range s.comments {
+		if (taskID != "" && c.TaskID == taskID) || (projectID != "" && c.ProjectID == projectID) {
+			comments = append(comments, *c)
+		}
+	}
+	writeJSON(w, http.StatusOK, comments)
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	var params todoist.CommentParams
+	if _, err := decodeBody(r, &params); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case params.TaskID != "" && s.taskIndex(params.TaskID) < 0:
+		badRequest(w, "task not found")
+		return
+	case params.ProjectID != "" && s.projectIndex(params.ProjectID) < 0:
+		badRequest(w, "project not found")
+		return
+	case params.TaskID == "" && params.ProjectID == "":
+		badRequest(w, "task_id or project_id is required")
+		return
+	}
+
+	comment := &todoist.Comment{
+		ID:         s.newID(),
+		TaskID:     params.TaskID,
+		ProjectID:  params.ProjectID,
+		Content:    params.Content,
+		PostedAt:   time.Now().UTC().Format(time.RFC3339),
+		Attachment: params.Attachment,
+	}
+	s.comments = append(s.comments, comment)
+	if params.TaskID != "" {
+		s.tasks[s.taskIndex(params.TaskID)].CommentCount++
+	} else {
+		s.projects[s.projectIndex(params.ProjectID)].CommentCount++
+	}
+	writeJSON(w, http.StatusOK, comment)
+}
+
+func (s *Server) getComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.commentIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.comments[i])
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request) {
+	var params todoist.CommentParams
+	fields, err := decodeBody(r, &params)
+	if err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.commentIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	if _, ok := fields["content"]; ok {
+		s.comments[i].Content = params.Content
+	}
+	writeJSON(w, http.StatusOK, s.comments[i])
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	i := s.commentIndex(id)
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	if c := s.comments[i]; c.TaskID != "" {
+		if t := s.taskIndex(c.TaskID); t >= 0 {
+			s.tasks[t].CommentCount--
+		}
+	} else if p := s.projectIndex(c.ProjectID); p >= 0 {
+		s.projects[p].CommentCount--
+	}
+	s.comments = removeWhere(s.comments, func(c *todoist.Comment) bool { return c.ID == id })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Labels
+
+func (s *Server) labelIndex(id string) int {
+	return find(s.labels, id, func(l *todoist.Label) string { return l.ID })
+}
+
+func (s *Server) listLabels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, snapshot(s.labels))
+}
+
+func (s *Server) createLabel(w http.ResponseWriter, r *http.Request) {
+	var params todoist.LabelParams
+	if _, err := decodeBody(r, &params); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+	if params.Name == "" {
+		badRequest(w, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, l := range s.labels {
+		if strings.EqualFold(l.Name, params.Name) {
+			badRequest(w, "label already exists")
+			return
+		}
+	}
+
+	label := &todoist.Label{
+		ID:         s.newID(),
+		Name:       params.Name,
+		Color:      params.Color,
+		Order:      params.Order,
+		IsFavorite: params.IsFavorite,
+	}
+	if label.Color == "" {
+		label.Color = todoist.ColorCharcoal
+	}
+	if label.Order == 0 {
+		label.Order = len(s.labels) + 1
+	}
+	s.labels = append(s.labels, label)
+	writeJSON(w, http.StatusOK, label)
+}
+
+func (s *Server) getLabel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.labelIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.labels[i])
+}
+
+func (s *Server) updateLabel(w http.ResponseWriter, r *http.Request) {
+	var params todoist.LabelParams
+	fields, err := decodeBody(r, &params)
+	if err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.labelIndex(r.PathValue("id"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	label := s.labels[i]
+	s.applyLabel(label, params, fields)
+	writeJSON(w, http.StatusOK, label)
+}
+
+// applyLabel updates the fields of a label that are present in a request. The caller holds s.mu.
+func (s *Server) applyLabel(label *todoist.Label, params todoist.LabelParams, fields map[string]json.RawMessage) {
+	if _, ok := fields["name"]; ok && params.Name != label.Name {
+		// Renaming a label renames it on all tasks, as in Todoist.
+		for _, t := range s.tasks {
+			for j, name := range t.Labels {
+				if name == label.Name {
+					t.Labels[j] = params.Name
+				}
+			}
+		}
+		label.Name = params.Name
+	}
+	if _, ok := fields["color"]; ok {
+		label.Color = params.Color
+	}
+	if _, ok := fields["order"]; ok {
+		label.Order = params.Order
+	}
+	if _, ok := fields["is_favorite"]; ok {
+		label.IsFavorite = params.IsFavorite
+	}
+}
+
+func (s *Server) deleteLabel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if s.labelIndex(id) < 0 {
+		notFound(w)
+		return
+	}
+	name := s.labels[s.labelIndex(id)].Name
+	for _, t := range s.tasks {
+		t.Labels = removeLabel(t.Labels, name)
+	}
+	s.labels = removeWhere(s.labels, func(l *todoist.Label) bool { return l.ID == id })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func contains(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+func removeLabel(labels []string, name string) []string {
+	kept := labels[:0]
+	for _, label := range labels {
+		if label != name {
+			kept = append(kept, label)
+		}
+	}
+	return kept
+}
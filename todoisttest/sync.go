@@ -0,0 +1,761 @@
+package todoisttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/filter"
+)
+
+// commandError is the status of a rejected Sync API command.
+type commandError struct {
+	Code     int    `json:"error_code"`
+	Message  string `json:"error"`
+	HTTPCode int    `json:"http_code"`
+}
+
+func invalidArgument(message string) *commandError {
+	return &commandError{Code: 20, Message: message, HTTPCode: http.StatusBadRequest}
+}
+
+func commandNotFound(message string) *commandError {
+	return &commandError{Code: 22, Message: message, HTTPCode: http.StatusNotFound}
+}
+
+// commandHandler applies a Sync API command. The caller holds s.mu.
+type commandHandler func(s *Server, cmd syncCommand) *commandError
+
+// commandHandlers are the Sync API commands understood by the server.
+var commandHandlers = map[string]commandHandler{
+	"item_move":         (*Server).moveItem,
+	"project_move":      (*Server).moveProject,
+	"project_update":    (*Server).updateProjectCommand,
+	"project_archive":   (*Server).archiveProject,
+	"project_unarchive": (*Server).unarchiveProject,
+	"label_update":      (*Server).updateLabelCommand,
+
+	"filter_add":           (*Server).addFilter,
+	"filter_update":        (*Server).updateFilter,
+	"filter_delete":        (*Server).deleteFilter,
+	"filter_update_orders": (*Server).reorderFilters,
+
+	"reminder_add":    (*Server).addReminder,
+	"reminder_update": (*Server).updateReminder,
+	"reminder_delete": (*Server).deleteReminder,
+}
+
+type syncCommand struct {
+	Type   string          `json:"type"`
+	UUID   string          `json:"uuid"`
+	TempID string          `json:"temp_id"`
+	Args   json.RawMessage `json:"args"`
+}
+
+// handleSync serves read requests, always as a full sync, and write commands.
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := map[string]interface{}{
+		"sync_token": strconv.Itoa(s.nextID),
+	}
+
+	if commands := r.PostForm.Get("commands"); commands != "" {
+		var cmds []syncCommand
+		if err := json.Unmarshal([]byte(commands), &cmds); err != nil {
+			badRequest(w, "invalid commands: %v", err)
+			return
+		}
+
+		status := make(map[string]interface{}, len(cmds))
+		tempIDs := make(map[string]string)
+		for _, cmd := range cmds {
+			handler, ok := commandHandlers[cmd.Type]
+			if !ok {
+				status[cmd.UUID] = invalidArgument("unknown command " + cmd.Type)
+				continue
+			}
+			if cmdErr := handler(s, cmd); cmdErr != nil {
+				status[cmd.UUID] = cmdErr
+				continue
+			}
+			status[cmd.UUID] = "ok"
+			if cmd.TempID != "" {
+				tempIDs[cmd.TempID] = strconv.Itoa(s.nextID)
+			}
+		}
+		response["sync_status"] = status
+		response["temp_id_mapping"] = tempIDs
+	}
+
+	if types := r.PostForm.Get("resource_types"); types != "" {
+		var resourceTypes []todoist.ResourceType
+		if err := json.Unmarshal([]byte(types), &resourceTypes); err != nil {
+			badRequest(w, "invalid resource_types: %v", err)
+			return
+		}
+		response["full_sync"] = true
+		for _, rt := range resourceTypes {
+			if data, ok := s.syncResource(rt); ok {
+				response[string(rt)] = data
+			}
+		}
+	}
+
+	response["sync_token"] = strconv.Itoa(s.nextID)
+	writeJSON(w, http.StatusOK, response)
+}
+
+// syncResource returns all records of a resource type in the Sync API format. The caller holds s.mu.
+func (s *Server) syncResource(rt todoist.ResourceType) (interface{}, bool) {
+	switch rt {
+	case todoist.ResourceProjects:
+		projects := make([]todoist.SyncProject, 0, len(s.projects))
+		for _, p := range s.projects {
+			if !s.archived[p.ID] {
+				projects = append(projects, s.syncProject(p))
+			}
+		}
+		return projects, true
+	case todoist.ResourceSections:
+		sections := make([]todoist.SyncSection, 0, len(s.sections))
+		for _, sec := range s.sections {
+			if !s.archived[sec.ProjectID] {
+				sections = append(sections, syncSection(sec))
+			}
+		}
+		return sections, true
+	case todoist.ResourceItems:
+		items := make([]todoist.SyncItem, 0, len(s.tasks))
+		for _, t := range s.tasks {
+			if t.IsCompleted || s.archived[t.ProjectID] {
+				continue
+			}
+			items = append(items, syncItem(t))
+		}
+		return items, true
+	case todoist.ResourceNotes, todoist.ResourceProjectNotes:
+		notes := []todoist.SyncNote{}
+		for _, c := range s.comments {
+			if (rt == todoist.ResourceNotes) != (c.TaskID != "") {
+				continue
+			}
+			notes = append(notes, s.syncNote(c))
+		}
+		return notes, true
+	case todoist.ResourceLabels:
+		labels := make([]todoist.SyncLabel, len(s.labels))
+		for i, l := range s.labels {
+			labels[i] = todoist.SyncLabel{ID: l.ID, Name: l.Name, Color: l.Color, ItemOrder: l.Order, IsFavorite: l.IsFavorite}
+		}
+		return labels, true
+	case todoist.ResourceFilters:
+		return snapshot(s.filters), true
+	case todoist.ResourceReminders:
+		return snapshot(s.reminders), true
+	}
+	return nil, false
+}
+
+// syncProject converts a project to the Sync API format. The caller holds s.mu.
+func (s *Server) syncProject(p *todoist.Project) todoist.SyncProject {
+	return todoist.SyncProject{
+		ID:           p.ID,
+		Name:         p.Name,
+		Color:        p.Color,
+		ParentID:     p.ParentID,
+		ChildOrder:   p.Order,
+		Shared:       p.IsShared,
+		IsArchived:   s.archived[p.ID],
+		IsFavorite:   p.IsFavorite,
+		ViewStyle:    p.ViewStyle,
+		InboxProject: p.IsInboxProject,
+		TeamInbox:    p.IsTeamInbox,
+	}
+}
+
+func syncSection(sec *todoist.Section) todoist.SyncSection {
+	return todoist.SyncSection{ID: sec.ID, Name: sec.Name, ProjectID: sec.ProjectID, SectionOrder: sec.Order}
+}
+
+func syncItem(t *todoist.Task) todoist.SyncItem {
+	return todoist.SyncItem{
+		ID:             t.ID,
+		ProjectID:      t.ProjectID,
+		SectionID:      t.SectionID,
+		ParentID:       t.ParentID,
+		Content:        t.Content,
+		Description:    t.Description,
+		Priority:       t.Priority,
+		Labels:         t.Labels,
+		ChildOrder:     t.Order,
+		Due:            t.Due,
+		Duration:       t.Duration,
+		AssignedByUID:  t.AssignerID,
+		ResponsibleUID: t.AssigneeID,
+		Checked:        t.IsCompleted,
+	}
+}
+
+// syncNote converts a comment to the Sync API format. The caller holds s.mu.
+func (s *Server) syncNote(c *todoist.Comment) todoist.SyncNote {
+	note := todoist.SyncNote{
+		ID:             c.ID,
+		ItemID:         c.TaskID,
+		ProjectID:      c.ProjectID,
+		Content:        c.Content,
+		PostedAt:       c.PostedAt,
+		FileAttachment: c.Attachment,
+	}
+	if c.TaskID != "" {
+		if i := s.taskIndex(c.TaskID); i >= 0 {
+			note.ProjectID = s.tasks[i].ProjectID
+		}
+	}
+	return note
+}
+
+// handleCompleted serves completed tasks, most recently completed first.
+func (s *Server) handleCompleted(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+	form := r.PostForm
+	limit, offset := 30, 0
+	if v := form.Get("limit"); v != "" {
+		limit, _ = strconv.Atoi(v)
+	}
+	if v := form.Get("offset"); v != "" {
+		offset, _ = strconv.Atoi(v)
+	}
+	if limit < 1 || limit > todoist.MaxCompletedTasksLimit || offset < 0 {
+		badRequest(w, "invalid limit or offset")
+		return
+	}
+	var since, until time.Time
+	for name, dst := range map[string]*time.Time{"since": &since, "until": &until} {
+		if v := form.Get(name); v != "" {
+			t, err := time.Parse("2006-01-02T15:04", v)
+			if err != nil {
+				badRequest(w, "invalid %s", name)
+				return
+			}
+			*dst = t
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tasks []*todoist.Task
+	for _, t := range s.tasks {
+		at, ok := s.closed[t.ID]
+		switch {
+		case !t.IsCompleted || !ok:
+		case form.Get("project_id") != "" && t.ProjectID != form.Get("project_id"):
+		case !since.IsZero() && at.Before(since), !until.IsZero() && at.After(until):
+		default:
+			tasks = append(tasks, t)
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return s.closed[tasks[i].ID].After(s.closed[tasks[j].ID]) })
+
+	items := []todoist.CompletedTask{}
+	for i := offset; i < len(tasks) && i < offset+limit; i++ {
+		t := tasks[i]
+		item := todoist.CompletedTask{
+			ID:          "c" + t.ID,
+			TaskID:      t.ID,
+			ProjectID:   t.ProjectID,
+			SectionID:   t.SectionID,
+			Content:     t.Content,
+			CompletedAt: s.closed[t.ID].Format("2006-01-02T15:04:05.000000Z"),
+		}
+		for _, c := range s.comments {
+			if c.TaskID != t.ID {
+				continue
+			}
+			item.NoteCount++
+			if form.Get("annotate_notes") == "true" {
+				item.Notes = append(item.Notes, s.syncNote(c))
+			}
+		}
+		if form.Get("annotate_items") == "true" {
+			full := syncItem(t)
+			item.Item = &full
+		}
+		items = append(items, item)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// handleArchivedProjects serves the archived projects.
+func (s *Server) handleArchivedProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projects := []todoist.SyncProject{}
+	for _, p := range s.projects {
+		if s.archived[p.ID] {
+			projects = append(projects, s.syncProject(p))
+		}
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// handleProjectData serves a project, archived or not, with its sections, active tasks and comments.
+func (s *Server) handleProjectData(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		badRequest(w, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PostForm.Get("project_id")
+	i := s.projectIndex(id)
+	if i < 0 {
+		notFound(w)
+		return
+	}
+
+	sections := []todoist.SyncSection{}
+	for _, sec := range s.sections {
+		if sec.ProjectID == id {
+			sections = append(sections, syncSection(sec))
+		}
+	}
+	items := []todoist.SyncItem{}
+	for _, t := range s.tasks {
+		if t.ProjectID == id && !t.IsCompleted {
+			items = append(items, syncItem(t))
+		}
+	}
+	notes, projectNotes := []todoist.SyncNote{}, []todoist.SyncNote{}
+	for _, c := range s.comments {
+		switch {
+		case c.ProjectID == id:
+			projectNotes = append(projectNotes, s.syncNote(c))
+		case c.TaskID != "":
+			if j := s.taskIndex(c.TaskID); j >= 0 && s.tasks[j].ProjectID == id && !s.tasks[j].IsCompleted {
+				notes = append(notes, s.syncNote(c))
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"project":       s.syncProject(s.projects[i]),
+		"sections":      sections,
+		"items":         items,
+		"notes":         notes,
+		"project_notes": projectNotes,
+	})
+}
+
+// archiveProject implements the project_archive command, which also archives the subprojects.
+func (s *Server) archiveProject(cmd syncCommand) *commandError {
+	i, cmdErr := s.projectArg(cmd)
+	if cmdErr != nil {
+		return cmdErr
+	}
+	if s.projects[i].IsInboxProject {
+		return invalidArgument("the inbox project cannot be archived")
+	}
+	var archive func(id string)
+	archive = func(id string) {
+		s.archived[id] = true
+		for _, p := range s.projects {
+			if p.ParentID != nil && *p.ParentID == id {
+				archive(p.ID)
+			}
+		}
+	}
+	archive(s.projects[i].ID)
+	return nil
+}
+
+func (s *Server) unarchiveProject(cmd syncCommand) *commandError {
+	i, cmdErr := s.projectArg(cmd)
+	if cmdErr != nil {
+		return cmdErr
+	}
+	delete(s.archived, s.projects[i].ID)
+	return nil
+}
+
+// projectArg returns the index of the project named by the id argument of a command.
+func (s *Server) projectArg(cmd syncCommand) (int, *commandError) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(cmd.Args, &args); err != nil {
+		return -1, invalidArgument(err.Error())
+	}
+	i := s.projectIndex(args.ID)
+	if i < 0 {
+		return -1, commandNotFound("project not found")
+	}
+	return i, nil
+}
+
+// moveItem implements the item_move command, moving a task and its subtasks.
+func (s *Server) moveItem(cmd syncCommand) *commandError {
+	var args struct {
+		ID string `json:"id"`
+		todoist.MoveTaskParams
+	}
+	if err := json.Unmarshal(cmd.Args, &args); err != nil {
+		return invalidArgument(err.Error())
+	}
+
+	i := s.taskIndex(args.ID)
+	if i < 0 {
+		return commandNotFound("task not found")
+	}
+	task := s.tasks[i]
+
+	switch {
+	case args.ProjectID != "":
+		if s.projectIndex(args.ProjectID) < 0 {
+			return commandNotFound("project not found")
+		}
+		task.ProjectID, task.SectionID, task.ParentID = args.ProjectID, "", ""
+	case args.SectionID != "":
+		j := s.sectionIndex(args.SectionID)
+		if j < 0 {
+			return commandNotFound("section not found")
+		}
+		task.ProjectID, task.SectionID, task.ParentID = s.sections[j].ProjectID, args.SectionID, ""
+	case args.ParentID != "":
+		j := s.taskIndex(args.ParentID)
+		if j < 0 || args.ParentID == task.ID {
+			return commandNotFound("parent task not found")
+		}
+		parent := s.tasks[j]
+		task.ProjectID, task.SectionID, task.ParentID = parent.ProjectID, parent.SectionID, parent.ID
+	default:
+		return invalidArgument("a project_id, section_id or parent_id is required")
+	}
+
+	s.moveSubtasks(task)
+	return nil
+}
+
+func (s *Server) moveProject(cmd syncCommand) *commandError {
+	var args struct {
+		ID       string  `json:"id"`
+		ParentID *string `json:"parent_id"`
+	}
+	if err := json.Unmarshal(cmd.Args, &args); err != nil {
+		return invalidArgument(err.Error())
+	}
+
+	i := s.projectIndex(args.ID)
+	if i < 0 {
+		return commandNotFound("project not found")
+	}
+	project := s.projects[i]
+	if args.ParentID == nil || *args.ParentID == "" {
+		project.ParentID = nil
+		return nil
+	}
+	if project.IsInboxProject {
+		return invalidArgument("the inbox project cannot be moved")
+	}
+	// The new parent must not be the project itself or one of its subprojects.
+	for id := *args.ParentID; ; {
+		if id == project.ID {
+			return invalidArgument("a project cannot be moved below itself")
+		}
+		j := s.projectIndex(id)
+		if j < 0 {
+			return commandNotFound("parent project not found")
+		}
+		if s.projects[j].ParentID == nil {
+			break
+		}
+		id = *s.projects[j].ParentID
+	}
+	parentID := *args.ParentID
+	project.ParentID = &parentID
+	return nil
+}
+
+// decodeUpdate reads the arguments of an update command into params and returns the fields
+// present in them, along with the ID of the updated object.
+func decodeUpdate(cmd syncCommand, params interface{}) (string, map[string]json.RawMessage, *commandError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(cmd.Args, &fields); err != nil {
+		return "", nil, invalidArgument(err.Error())
+	}
+	var id string
+	if err := json.Unmarshal(fields["id"], &id); err != nil {
+		return "", nil, invalidArgument("id is required")
+	}
+	if err := json.Unmarshal(cmd.Args, params); err != nil {
+		return "", nil, invalidArgument(err.Error())
+	}
+	return id, fields, nil
+}
+
+func (s *Server) updateProjectCommand(cmd syncCommand) *commandError {
+	var params todoist.ProjectParams
+	id, fields, cmdErr := decodeUpdate(cmd, &params)
+	if cmdErr != nil {
+		return cmdErr
+	}
+	i := s.projectIndex(id)
+	if i < 0 {
+		return commandNotFound("project not found")
+	}
+	applyProject(s.projects[i], params, fields)
+	return nil
+}
+
+func (s *Server) updateLabelCommand(cmd syncCommand) *commandError {
+	var params todoist.LabelParams
+	id, fields, cmdErr := decodeUpdate(cmd, &params)
+	if cmdErr != nil {
+		return cmdErr
+	}
+	i := s.labelIndex(id)
+	if i < 0 {
+		return commandNotFound("label not found")
+	}
+	s.applyLabel(s.labels[i], params, fields)
+	return nil
+}
+
+func (s *Server) filterIndex(id string) int {
+	return find(s.filters, id, func(f *todoist.Filter) string { return f.ID })
+}
+
+// checkFilter rejects filters with an empty name or a query the filter package cannot parse.
+func checkFilter(f *todoist.Filter) *commandError {
+	if f.Name == "" {
+		return invalidArgument("name is required")
+	}
+	if _, err := filter.Parse(f.Query); err != nil {
+		return invalidArgument(err.Error())
+	}
+	return nil
+}
+
+func (s *Server) addFilter(cmd syncCommand) *commandError {
+	var params todoist.FilterParams
+	if err := json.Unmarshal(cmd.Args, &params); err != nil {
+		return invalidArgument(err.Error())
+	}
+
+	f := &todoist.Filter{Name: params.Name, Query: params.Query, Color: params.Color}
+	if params.Order != nil {
+		f.Order = *params.Order
+	}
+	if params.IsFavorite != nil {
+		f.IsFavorite = *params.IsFavorite
+	}
+	if f.Color == "" {
+		f.Color = todoist.ColorCharcoal
+	}
+	if f.Order == 0 {
+		f.Order = len(s.filters) + 1
+	}
+	if cmdErr := checkFilter(f); cmdErr != nil {
+		return cmdErr
+	}
+	f.ID = s.newID()
+	s.filters = append(s.filters, f)
+	return nil
+}
+
+func (s *Server) updateFilter(cmd syncCommand) *commandError {
+	var params todoist.FilterParams
+	id, fields, cmdErr := decodeUpdate(cmd, &params)
+	if cmdErr != nil {
+		return cmdErr
+	}
+	i := s.filterIndex(id)
+	if i < 0 {
+		return commandNotFound("filter not found")
+	}
+
+	updated := *s.filters[i]
+	if _, ok := fields["name"]; ok {
+		updated.Name = params.Name
+	}
+	if _, ok := fields["query"]; ok {
+		updated.Query = params.Query
+	}
+	if _, ok := fields["color"]; ok {
+		updated.Color = params.Color
+	}
+	if params.Order != nil {
+		updated.Order = *params.Order
+	}
+	if params.IsFavorite != nil {
+		updated.IsFavorite = *params.IsFavorite
+	}
+	if cmdErr := checkFilter(&updated); cmdErr != nil {
+		return cmdErr
+	}
+	*s.filters[i] = updated
+	return nil
+}
+
+func (s *Server) deleteFilter(cmd syncCommand) *commandError {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(cmd.Args, &args); err != nil {
+		return invalidArgument(err.Error())
+	}
+	if s.filterIndex(args.ID) < 0 {
+		return commandNotFound("filter not found")
+	}
+	s.filters = removeWhere(s.filters, func(f *todoist.Filter) bool { return f.ID == args.ID })
+	return nil
+}
+
+func (s *Server) reorderFilters(cmd syncCommand) *commandError {
+	var args struct {
+		IDOrderMapping map[string]int `json:"id_order_mapping"`
+	}
+	if err := json.Unmarshal(cmd.Args, &args); err != nil {
+		return invalidArgument(err.Error())
+	}
+	for id := range args.IDOrderMapping {
+		if s.filterIndex(id) < 0 {
+			return commandNotFound("filter not found")
+		}
+	}
+	for id, order := range args.IDOrderMapping {
+		s.filters[s.filterIndex(id)].Order = order
+	}
+	return nil
+}
+
+func (s *Server) reminderIndex(id string) int {
+	return find(s.reminders, id, func(r *todoist.Reminder) string { return r.ID })
+}
+
+// checkReminder rejects reminders missing the fields of their type, and relative reminders of
+// tasks without a due time.
+func (s *Server) checkReminder(r *todoist.Reminder) *commandError {
+	i := s.taskIndex(r.TaskID)
+	if i < 0 {
+		return commandNotFound("task not found")
+	}
+	switch r.Type {
+	case todoist.ReminderRelative:
+		if due := s.tasks[i].Due; due == nil || due.Datetime == "" {
+			return invalidArgument("relative reminders require a task due at a time")
+		}
+	case todoist.ReminderAbsolute:
+		if r.Due == nil {
+			return invalidArgument("due is required")
+		}
+		if _, err := time.Parse(time.RFC3339, r.Due.Date); err != nil {
+			return invalidArgument("invalid due date")
+		}
+	case todoist.ReminderLocation:
+		if r.Name == "" || r.Latitude == "" || r.Longitude == "" || r.Trigger == "" {
+			return invalidArgument("name, loc_lat, loc_long and loc_trigger are required")
+		}
+	default:
+		return invalidArgument("invalid reminder type")
+	}
+	return nil
+}
+
+func (s *Server) addReminder(cmd syncCommand) *commandError {
+	var r todoist.Reminder
+	if err := json.Unmarshal(cmd.Args, &r); err != nil {
+		return invalidArgument(err.Error())
+	}
+	if cmdErr := s.checkReminder(&r); cmdErr != nil {
+		return cmdErr
+	}
+	r.ID = s.newID()
+	s.reminders = append(s.reminders, &r)
+	return nil
+}
+
+func (s *Server) updateReminder(cmd syncCommand) *commandError {
+	var params todoist.Reminder
+	id, fields, cmdErr := decodeUpdate(cmd, &params)
+	if cmdErr != nil {
+		return cmdErr
+	}
+	i := s.reminderIndex(id)
+	if i < 0 {
+		return commandNotFound("reminder not found")
+	}
+
+	updated := *s.reminders[i]
+	if _, ok := fields["type"]; ok {
+		updated.Type = params.Type
+	}
+	if _, ok := fields["notify_uid"]; ok {
+		updated.NotifyUID = params.NotifyUID
+	}
+	if _, ok := fields["minute_offset"]; ok {
+		updated.MinuteOffset = params.MinuteOffset
+	}
+	if _, ok := fields["due"]; ok {
+		updated.Due = params.Due
+	}
+	if _, ok := fields["name"]; ok {
+		updated.Name = params.Name
+	}
+	if _, ok := fields["loc_lat"]; ok {
+		updated.Latitude = params.Latitude
+	}
+	if _, ok := fields["loc_long"]; ok {
+		updated.Longitude = params.Longitude
+	}
+	if _, ok := fields["loc_trigger"]; ok {
+		updated.Trigger = params.Trigger
+	}
+	if _, ok := fields["radius"]; ok {
+		updated.Radius = params.Radius
+	}
+	if cmdErr := s.checkReminder(&updated); cmdErr != nil {
+		return cmdErr
+	}
+	*s.reminders[i] = updated
+	return nil
+}
+
+func (s *Server) deleteReminder(cmd syncCommand) *commandError {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(cmd.Args, &args); err != nil {
+		return invalidArgument(err.Error())
+	}
+	if s.reminderIndex(args.ID) < 0 {
+		return commandNotFound("reminder not found")
+	}
+	s.reminders = removeWhere(s.reminders, func(r *todoist.Reminder) bool { return r.ID == args.ID })
+	return nil
+}
+
+// moveSubtasks moves the subtasks of a task, recursively, to the task's project and section.
+func (s *Server) moveSubtasks(parent *todoist.Task) {
+	for _, t := range s.tasks {
+		if t.ParentID == parent.ID {
+			t.ProjectID, t.SectionID = parent.ProjectID, parent.SectionID
+			s.moveSubtasks(t)
+		}
+	}
+}
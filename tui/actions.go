@@ -0,0 +1,410 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// tempPrefix marks the IDs of tasks and comments that are still being created.
+const tempPrefix = "tmp-"
+
+func (u *UI) tempID() string {
+	u.nextTemp++
+	return tempPrefix + strconv.Itoa(u.nextTemp)
+}
+
+func isTemp(id string) bool {
+	return strings.HasPrefix(id, tempPrefix)
+}
+
+func sprintf(format string, args ...interface{}) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// editableTask returns the selected task unless it is still being created.
+func (u *UI) editableTask() (todoist.Task, bool) {
+	i, ok := u.selectedTask()
+	if !ok {
+		return todoist.Task{}, false
+	}
+	t := u.tasks[i]
+	if isTemp(t.ID) {
+		u.setError("%q is still being saved", t.Content)
+		return todoist.Task{}, false
+	}
+	return t, true
+}
+
+// toggleCompleted closes the selected task and its subtasks, or reopens it if it was closed in this session.
+func (u *UI) toggleCompleted() {
+	t, ok := u.editableTask()
+	if !ok {
+		return
+	}
+
+	completing := !t.IsCompleted
+	ids := []string{t.ID}
+	if completing {
+		ids = append(ids, u.descendants(t.ID)...)
+	}
+
+	previous := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if i := u.taskIndex(id); i >= 0 {
+			previous[id] = u.tasks[i].IsCompleted
+			u.tasks[i].IsCompleted = completing
+		}
+	}
+
+	u.change(func() error {
+		var err error
+		if completing {
+			_, err = u.client.CloseTask(t.ID)
+		} else {
+			_, err = u.client.ReopenTask(t.ID)
+		}
+		return err
+	}, func(err error) {
+		if err != nil {
+			for id, completed := range previous {
+				if i := u.taskIndex(id); i >= 0 {
+					u.tasks[i].IsCompleted = completed
+				}
+			}
+			u.setError("Failed to update %q, reverted: %v", t.Content, err)
+			return
+		}
+		if completing {
+			u.setStatus("Completed %q", t.Content)
+		} else {
+			u.setStatus("Reopened %q", t.Content)
+		}
+	})
+}
+
+// editKind names the task field edited in a prompt.
+type editKind int
+
+const (
+	editContent editKind = iota
+	editDue
+	editPriority
+	editLabels
+)
+
+// promptEdit opens the inline editor for a field of the selected task.
+func (u *UI) promptEdit(label string, kind editKind) {
+	t, ok := u.editableTask()
+	if !ok {
+		return
+	}
+
+	var value string
+	switch kind {
+	case editContent:
+		value = t.Content
+	case editDue:
+		if t.Due != nil {
+			value = t.Due.String
+		}
+	case editPriority:
+		value = t.Priority.String()
+	case editLabels:
+		value = strings.Join(t.Labels, ", ")
+	}
+
+	u.prompt = &prompt{
+		label:  label,
+		input:  []rune(value),
+		submit: func(value string) { u.editTask(t.ID, kind, value) },
+	}
+}
+
+// editTask applies an edit locally, sends it and rolls the field back if the update fails.
+func (u *UI) editTask(id string, kind editKind, value string) {
+	i := u.taskIndex(id)
+	if i < 0 {
+		return
+	}
+	prev := u.tasks[i]
+	next := prev
+
+	var params todoist.TaskParams
+	switch kind {
+	case editContent:
+		if strings.TrimSpace(value) == "" {
+			u.setError("Content cannot be empty")
+			return
+		}
+		params.Content = value
+		next.Content = value
+	case editDue:
+		if strings.TrimSpace(value) == "" {
+			params.DueString = "no date"
+			next.Due = nil
+		} else {
+			params.DueString = value
+			next.Due = &todoist.TaskDue{String: value}
+		}
+	case editPriority:
+		priority, err := todoist.ParsePriority(value)
+		if err != nil {
+			u.setError("%v", err)
+			return
+		}
+		params.Priority = priority
+		next.Priority = priority
+	case editLabels:
+		labels := splitLabels(value)
+		params.Labels = labels
+		next.Labels = labels
+	}
+
+	copyField(kind, &u.tasks[i], next)
+
+	var updated *todoist.Task
+	u.change(func() error {
+		var err error
+		if kind == editLabels && len(params.Labels) == 0 {
+			updated, err = u.client.ClearTaskLabels(id)
+		} else {
+			updated, err = u.client.UpdateTask(id, params)
+		}
+		return err
+	}, func(err error) {
+		j := u.taskIndex(id)
+		if err != nil {
+			if j >= 0 {
+				copyField(kind, &u.tasks[j], prev)
+			}
+			u.setError("Failed to update %q, reverted: %v", prev.Content, err)
+			return
+		}
+		if j >= 0 {
+			copyField(kind, &u.tasks[j], *updated)
+		}
+		u.setStatus("Updated %q", updated.Content)
+	})
+}
+
+// copyField copies the edited field only, so concurrent edits of other fields are not undone by a rollback.
+func copyField(kind editKind, dst *todoist.Task, src todoist.Task) {
+	switch kind {
+	case editContent:
+		dst.Content = src.Content
+	case editDue:
+		dst.Due = src.Due
+	case editPriority:
+		dst.Priority = src.Priority
+	case editLabels:
+		dst.Labels = src.Labels
+	}
+}
+
+func splitLabels(s string) []string {
+	var labels []string
+	for _, label := range strings.Split(s, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// location is where a task lives, restored when a move fails.
+type location struct {
+	projectID, sectionID, parentID string
+}
+
+// startMove lets the user pick a project or section in the tree to move the selected task to.
+func (u *UI) startMove() {
+	t, ok := u.editableTask()
+	if !ok {
+		return
+	}
+	u.movingID = t.ID
+	u.moveIdx = u.nodeIdx
+	u.setStatus("Move %q: pick a project or section, Enter to move, Esc to cancel", t.Content)
+}
+
+func (u *UI) cancelMove() {
+	u.movingID = ""
+	u.setStatus("")
+}
+
+func (u *UI) finishMove() {
+	id := u.movingID
+	u.cancelMove()
+
+	i := u.taskIndex(id)
+	if u.moveIdx >= len(u.nodes) || i < 0 {
+		return
+	}
+	target := u.nodes[u.moveIdx]
+	t := u.tasks[i]
+
+	params := todoist.MoveTaskParams{ProjectID: target.project.ID}
+	sectionID := ""
+	if target.section != nil {
+		params = todoist.MoveTaskParams{SectionID: target.section.ID}
+		sectionID = target.section.ID
+	}
+	if t.ProjectID == target.project.ID && t.SectionID == sectionID && t.ParentID == "" {
+		return
+	}
+
+	previous := make(map[string]location)
+	for _, moved := range append([]string{id}, u.descendants(id)...) {
+		j := u.taskIndex(moved)
+		previous[moved] = location{u.tasks[j].ProjectID, u.tasks[j].SectionID, u.tasks[j].ParentID}
+		u.tasks[j].ProjectID = target.project.ID
+		u.tasks[j].SectionID = sectionID
+	}
+	u.tasks[i].ParentID = ""
+	u.taskIdx = clamp(u.taskIdx, len(u.taskRows()))
+
+	u.change(func() error {
+		_, err := u.client.MoveTask(id, params)
+		return err
+	}, func(err error) {
+		if err != nil {
+			for moved, loc := range previous {
+				if j := u.taskIndex(moved); j >= 0 {
+					u.tasks[j].ProjectID, u.tasks[j].SectionID, u.tasks[j].ParentID = loc.projectID, loc.sectionID, loc.parentID
+				}
+			}
+			u.setError("Failed to move %q, reverted: %v", t.Content, err)
+			return
+		}
+		u.setStatus("Moved %q to %s", t.Content, nodeName(target))
+	})
+}
+
+// promptAddTask asks for the content of a new task in the selected project or section.
+func (u *UI) promptAddTask() {
+	target, ok := u.selectedNode()
+	if !ok {
+		return
+	}
+	u.prompt = &prompt{
+		label:  "New task in " + nodeName(target),
+		submit: func(content string) { u.addTask(target, content) },
+	}
+}
+
+func (u *UI) addTask(target node, content string) {
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+
+	params := todoist.TaskParams{Content: content, ProjectID: target.project.ID}
+	temp := todoist.Task{
+		ID:        u.tempID(),
+		ProjectID: target.project.ID,
+		Content:   content,
+		Priority:  todoist.PriorityP4,
+		Order:     int(^uint(0) >> 1),
+	}
+	if target.section != nil {
+		params.SectionID = target.section.ID
+		temp.SectionID = target.section.ID
+	}
+	if target.project.ID == u.taskProjectID {
+		u.tasks = append(u.tasks, temp)
+	}
+
+	var created *todoist.Task
+	u.change(func() error {
+		var err error
+		created, err = u.client.CreateTask(params)
+		return err
+	}, func(err error) {
+		i := u.taskIndex(temp.ID)
+		if err != nil {
+			if i >= 0 {
+				u.tasks = append(u.tasks[:i], u.tasks[i+1:]...)
+			}
+			u.setError("Failed to add %q: %v", content, err)
+			return
+		}
+		if i >= 0 {
+			u.tasks[i] = *created
+		}
+		u.setStatus("Added %q", content)
+	})
+}
+
+// openComments shows the comments of the selected task.
+func (u *UI) openComments() {
+	t, ok := u.editableTask()
+	if !ok {
+		return
+	}
+	u.loadComments(t.ID)
+}
+
+func (u *UI) promptAddComment() {
+	taskID := u.commentTaskID
+	u.prompt = &prompt{
+		label:  "Comment",
+		submit: func(content string) { u.addComment(taskID, content) },
+	}
+}
+
+func (u *UI) addComment(taskID, content string) {
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+
+	temp := todoist.Comment{
+		ID:       u.tempID(),
+		TaskID:   taskID,
+		Content:  content,
+		PostedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	u.comments = append(u.comments, temp)
+	u.commentIdx = len(u.comments) - 1
+
+	var created *todoist.Comment
+	u.change(func() error {
+		var err error
+		created, err = u.client.CreateComment(todoist.CommentParams{TaskID: taskID, Content: content})
+		return err
+	}, func(err error) {
+		i := -1
+		for j, c := range u.comments {
+			if c.ID == temp.ID {
+				i = j
+			}
+		}
+		if err != nil {
+			if i >= 0 {
+				u.comments = append(u.comments[:i], u.comments[i+1:]...)
+				u.commentIdx = clamp(u.commentIdx, len(u.comments))
+			}
+			u.setError("Failed to add comment: %v", err)
+			return
+		}
+		if i >= 0 {
+			u.comments[i] = *created
+		}
+		if t := u.taskIndex(taskID); t >= 0 {
+			u.tasks[t].CommentCount++
+		}
+		u.setStatus("Comment added")
+	})
+}
+
+func nodeName(n node) string {
+	if n.section != nil {
+		return n.project.Name + " / " + n.section.Name
+	}
+	return n.project.Name
+}
@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+type key int
+
+const (
+	keyRune key = iota
+	keyEnter
+	keyEsc
+	keyTab
+	keyBackspace
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyCtrlC
+)
+
+type keyEvent struct {
+	key  key
+	char rune
+}
+
+// readKeys decodes key presses from r until it fails or ends, then closes keys.
+// It stops early once done is closed.
+func readKeys(r io.Reader, keys chan<- keyEvent, done <-chan struct{}) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+	for {
+		n, err := r.Read(buf)
+		for _, k := range parseKeys(buf[:n]) {
+			select {
+			case keys <- k:
+			case <-done:
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parseKeys decodes the bytes of one read from a terminal in raw mode.
+func parseKeys(b []byte) []keyEvent {
+	var keys []keyEvent
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) >= 3 && (b[1] == '[' || b[1] == 'O') {
+				if k, ok := arrowKeys[b[2]]; ok {
+					keys = append(keys, keyEvent{key: k})
+				}
+				b = b[3:]
+				continue
+			}
+			keys = append(keys, keyEvent{key: keyEsc})
+		case c == '\r' || c == '\n':
+			keys = append(keys, keyEvent{key: keyEnter})
+			if c == '\r' && len(b) > 1 && b[1] == '\n' {
+				b = b[1:]
+			}
+		case c == '\t':
+			keys = append(keys, keyEvent{key: keyTab})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, keyEvent{key: keyBackspace})
+		case c == 0x03:
+			keys = append(keys, keyEvent{key: keyCtrlC})
+		case c < 0x20:
+			// Other control characters are ignored.
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, keyEvent{key: keyRune, char: r})
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+var arrowKeys = map[byte]key{'A': keyUp, 'B': keyDown, 'C': keyRight, 'D': keyLeft}
+
+// handleKey dispatches a key press according to the current mode and focus.
+func (u *UI) handleKey(k keyEvent) {
+	if k.key == keyCtrlC {
+		u.quit = true
+		return
+	}
+	if u.prompt != nil {
+		u.handlePromptKey(k)
+		return
+	}
+	if u.movingID != "" {
+		u.handleMoveKey(k)
+		return
+	}
+
+	if k.key == keyRune {
+		switch k.char {
+		case 'q':
+			u.quit = true
+			return
+		case '?':
+			u.showHelp = !u.showHelp
+			return
+		case 'r':
+			u.reload()
+			return
+		}
+	}
+	if k.key == keyTab {
+		if u.focus == focusTree {
+			u.focus = focusTasks
+		} else {
+			u.focus = focusTree
+		}
+		return
+	}
+
+	switch u.focus {
+	case focusTree:
+		u.handleTreeKey(k)
+	case focusTasks:
+		u.handleTaskKey(k)
+	case focusComments:
+		u.handleCommentKey(k)
+	}
+}
+
+func (u *UI) handleTreeKey(k keyEvent) {
+	switch {
+	case k.key == keyUp || k.char == 'k':
+		u.selectNode(u.nodeIdx - 1)
+	case k.key == keyDown || k.char == 'j':
+		u.selectNode(u.nodeIdx + 1)
+	case k.key == keyEnter || k.key == keyRight || k.char == 'l':
+		u.focus = focusTasks
+	case k.char == 'a':
+		u.promptAddTask()
+	}
+}
+
+func (u *UI) selectNode(i int) {
+	u.nodeIdx = clamp(i, len(u.nodes))
+	u.taskIdx = 0
+	u.loadTasks()
+}
+
+func (u *UI) handleTaskKey(k keyEvent) {
+	switch {
+	case k.key == keyUp || k.char == 'k':
+		u.taskIdx = clamp(u.taskIdx-1, len(u.taskRows()))
+	case k.key == keyDown || k.char == 'j':
+		u.taskIdx = clamp(u.taskIdx+1, len(u.taskRows()))
+	case k.key == keyLeft || k.key == keyEsc || k.char == 'h':
+		u.focus = focusTree
+	case k.char == 'a':
+		u.promptAddTask()
+	case k.key == keyEnter || k.char == 'c':
+		u.openComments()
+	case k.char == 'x':
+		u.toggleCompleted()
+	case k.char == 'e':
+		u.promptEdit("Content", editContent)
+	case k.char == 'd':
+		u.promptEdit("Due", editDue)
+	case k.char == 'p':
+		u.promptEdit("Priority (P1-P4)", editPriority)
+	case k.char == 'L':
+		u.promptEdit("Labels", editLabels)
+	case k.char == 'm':
+		u.startMove()
+	}
+}
+
+func (u *UI) handleCommentKey(k keyEvent) {
+	switch {
+	case k.key == keyUp || k.char == 'k':
+		u.commentIdx = clamp(u.commentIdx-1, len(u.comments))
+	case k.key == keyDown || k.char == 'j':
+		u.commentIdx = clamp(u.commentIdx+1, len(u.comments))
+	case k.key == keyEsc || k.key == keyLeft || k.char == 'h':
+		u.focus = focusTasks
+		u.commentTaskID = ""
+	case k.char == 'a':
+		u.promptAddComment()
+	}
+}
+
+func (u *UI) handleMoveKey(k keyEvent) {
+	switch {
+	case k.key == keyUp || k.char == 'k':
+		u.moveIdx = clamp(u.moveIdx-1, len(u.nodes))
+	case k.key == keyDown || k.char == 'j':
+		u.moveIdx = clamp(u.moveIdx+1, len(u.nodes))
+	case k.key == keyEnter:
+		u.finishMove()
+	case k.key == keyEsc:
+		u.cancelMove()
+	}
+}
+
+func (u *UI) handlePromptKey(k keyEvent) {
+	p := u.prompt
+	switch k.key {
+	case keyEnter:
+		u.prompt = nil
+		p.submit(string(p.input))
+	case keyEsc:
+		u.prompt = nil
+		u.setStatus("")
+	case keyBackspace:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+	case keyRune:
+		p.input = append(p.input, k.char)
+	}
+}
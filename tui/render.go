@@ -0,0 +1,369 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+	ansiRed     = "\x1b[31m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiCyan    = "\x1b[36m"
+)
+
+var priorityStyles = map[todoist.Priority]string{
+	todoist.PriorityP1: ansiRed,
+	todoist.PriorityP2: ansiYellow,
+	todoist.PriorityP3: ansiBlue,
+}
+
+// render redraws the whole screen.
+func (u *UI) render() {
+	width, height := u.size()
+	if width < 40 {
+		width = 40
+	}
+	if height < 8 {
+		height = 8
+	}
+
+	bodyHeight := height - 3
+	treeWidth := width / 3
+	if treeWidth > 32 {
+		treeWidth = 32
+	}
+	mainWidth := width - treeWidth - 1
+
+	left := u.treeLines(treeWidth, bodyHeight)
+	var right []string
+	switch {
+	case u.showHelp:
+		right = helpLines(mainWidth, bodyHeight)
+	case u.focus == focusComments:
+		right = u.commentLines(mainWidth, bodyHeight)
+	default:
+		right = u.taskLines(mainWidth, bodyHeight)
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	b.WriteString(styled(ansiReverse+ansiBold, cell(" Todoist", width)))
+	b.WriteString("\r\n")
+	for i := 0; i < bodyHeight; i++ {
+		b.WriteString(left[i])
+		b.WriteString(styled(ansiDim, "│"))
+		b.WriteString(right[i])
+		b.WriteString("\r\n")
+	}
+	b.WriteString(u.statusLine(width))
+	b.WriteString("\r\n")
+	b.WriteString(styled(ansiDim, cell(u.keyHelp(), width)))
+
+	io.WriteString(u.out, b.String())
+}
+
+func (u *UI) treeLines(width, height int) []string {
+	lines := make([]string, 0, height)
+
+	selected := u.nodeIdx
+	if u.movingID != "" {
+		selected = u.moveIdx
+	}
+	start, end := window(len(u.nodes), selected, height)
+
+	for i := start; i < end; i++ {
+		n := u.nodes[i]
+		name := n.project.Name
+		if n.section != nil {
+			name = "/ " + n.section.Name
+		}
+		text := cell(" "+strings.Repeat("  ", n.depth)+name, width)
+
+		switch {
+		case u.movingID != "" && i == u.moveIdx:
+			text = styled(ansiReverse+ansiCyan, text)
+		case i == u.nodeIdx && u.focus == focusTree:
+			text = styled(ansiReverse, text)
+		case i == u.nodeIdx:
+			text = styled(ansiBold, text)
+		case n.section != nil:
+			text = styled(ansiDim, text)
+		}
+		lines = append(lines, text)
+	}
+
+	return fill(lines, width, height)
+}
+
+// Widths of the task list columns.
+const (
+	dueWidth      = 16
+	priorityWidth = 2
+	labelsWidth   = 18
+)
+
+func (u *UI) taskLines(width, height int) []string {
+	lines := make([]string, 0, height)
+
+	n, ok := u.selectedNode()
+	if !ok {
+		return fill(lines, width, height)
+	}
+	lines = append(lines, styled(ansiBold, cell(" "+nodeName(n), width)))
+
+	labels := labelsWidth
+	contentWidth := width - 6 - dueWidth - priorityWidth - labels - 3
+	if contentWidth < 16 {
+		labels = 0
+		contentWidth = width - 6 - dueWidth - priorityWidth - 2
+	}
+	header := " " + cell("    TASK", contentWidth+4) + " " + cell("DUE", dueWidth) + " " + cell("PR", priorityWidth)
+	if labels > 0 {
+		header += " " + cell("LABELS", labels)
+	}
+	lines = append(lines, styled(ansiDim, cell(header, width)))
+
+	rows := u.taskRows()
+	if len(rows) == 0 {
+		lines = append(lines, styled(ansiDim, cell(" No tasks", width)))
+		return fill(lines, width, height)
+	}
+
+	start, end := window(len(rows), u.taskIdx, height-len(lines))
+	for i := start; i < end; i++ {
+		t := u.tasks[u.taskIndex(rows[i].id)]
+
+		mark := "[ ] "
+		if t.IsCompleted {
+			mark = "[x] "
+		}
+		content := strings.Repeat("  ", rows[i].depth) + t.Content
+		if t.CommentCount > 0 {
+			content += fmt.Sprintf(" (%d)", t.CommentCount)
+		}
+
+		line := " " + mark + cell(content, contentWidth) + " " + cell(formatDue(t.Due), dueWidth) + " "
+		prio := cell(t.Priority.String(), priorityWidth)
+		if t.Priority == todoist.PriorityP4 {
+			prio = cell("", priorityWidth)
+		}
+		rest := ""
+		if labels > 0 {
+			rest = " " + cell(formatLabels(t.Labels), labels)
+		}
+		padding := cell("", width-utf8.RuneCountInString(line)-priorityWidth-utf8.RuneCountInString(rest))
+
+		switch {
+		case i == u.taskIdx && u.focus == focusTasks:
+			lines = append(lines, styled(ansiReverse, line+prio+rest+padding))
+		case t.IsCompleted || isTemp(t.ID):
+			lines = append(lines, styled(ansiDim, line+prio+rest+padding))
+		default:
+			if style, ok := priorityStyles[t.Priority]; ok {
+				prio = styled(style, prio)
+			}
+			if i == u.taskIdx {
+				line = styled(ansiBold, line)
+			}
+			lines = append(lines, line+prio+rest+padding)
+		}
+	}
+
+	return fill(lines, width, height)
+}
+
+func (u *UI) commentLines(width, height int) []string {
+	var lines []string
+	selectedLine := 0
+
+	title := "Comments"
+	if i := u.taskIndex(u.commentTaskID); i >= 0 {
+		title += ": " + u.tasks[i].Content
+	}
+	header := []string{styled(ansiBold, cell(" "+title, width)), cell("", width)}
+
+	if len(u.comments) == 0 {
+		lines = append(lines, styled(ansiDim, cell(" No comments", width)))
+	}
+	for i, c := range u.comments {
+		if i == u.commentIdx {
+			selectedLine = len(lines)
+		}
+		posted := " " + formatTime(c.PostedAt)
+		if c.Attachment != nil {
+			posted += " · " + c.Attachment.FileName
+		}
+		if i == u.commentIdx {
+			lines = append(lines, styled(ansiReverse, cell(posted, width)))
+		} else {
+			lines = append(lines, styled(ansiDim, cell(posted, width)))
+		}
+		for _, text := range wrap(c.Content, width-2) {
+			lines = append(lines, cell(" "+text, width))
+		}
+		lines = append(lines, cell("", width))
+	}
+
+	start, end := window(len(lines), selectedLine, height-len(header))
+	return fill(append(header, lines[start:end]...), width, height)
+}
+
+func helpLines(width, height int) []string {
+	help := []string{
+		"Keys",
+		"",
+		"tab          switch between tree and tasks",
+		"j/k, ↑/↓     move the selection",
+		"a            add a task to the selected project or section",
+		"x            complete or reopen the task",
+		"e            edit the content",
+		"d            edit the due date, empty to remove it",
+		"p            set the priority",
+		"L            edit the labels, comma separated",
+		"m            move the task to another project or section",
+		"c, enter     show comments, a to add one",
+		"r            reload",
+		"?            toggle this help",
+		"q            quit",
+	}
+
+	lines := make([]string, 0, height)
+	for _, line := range help {
+		lines = append(lines, cell(" "+line, width))
+	}
+	return fill(lines, width, height)
+}
+
+func (u *UI) statusLine(width int) string {
+	if u.prompt != nil {
+		text := " " + u.prompt.label + ": " + string(u.prompt.input)
+		return cell(text, width-1) + styled(ansiReverse, " ")
+	}
+
+	text := " " + u.status
+	if u.pending > 0 {
+		text += fmt.Sprintf("  (saving %d…)", u.pending)
+	}
+	if u.statusErr {
+		return styled(ansiRed, cell(text, width))
+	}
+	return cell(text, width)
+}
+
+func (u *UI) keyHelp() string {
+	switch {
+	case u.prompt != nil:
+		return " enter save  esc cancel"
+	case u.movingID != "":
+		return " j/k pick target  enter move  esc cancel"
+	case u.focus == focusTree:
+		return " j/k select  enter tasks  a add  tab switch  r reload  ? help  q quit"
+	case u.focus == focusComments:
+		return " j/k select  a add comment  esc back  q quit"
+	}
+	return " x done  e edit  d due  p priority  L labels  m move  a add  c comments  ? help  q quit"
+}
+
+// window returns the range of n rows to show so that the selected row is visible in height rows.
+func window(n, selected, height int) (int, int) {
+	if height <= 0 {
+		return 0, 0
+	}
+	start := 0
+	if selected >= height {
+		start = selected - height + 1
+	}
+	end := start + height
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// fill pads a pane to its height with empty lines.
+func fill(lines []string, width, height int) []string {
+	if len(lines) > height {
+		return lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, cell("", width))
+	}
+	return lines
+}
+
+// cell truncates or pads text to exactly width columns.
+func cell(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := utf8.RuneCountInString(text)
+	if n > width {
+		runes := []rune(text)
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-n)
+}
+
+func styled(style, text string) string {
+	return style + text + ansiReset
+}
+
+// wrap breaks text into lines of at most width columns at spaces.
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func formatDue(due *todoist.TaskDue) string {
+	switch {
+	case due == nil:
+		return ""
+	case due.Datetime != "":
+		if t, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+		return due.Datetime
+	case due.Date != "":
+		return due.Date
+	}
+	return due.String
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return "@" + strings.Join(labels, " @")
+}
+
+func formatTime(value string) string {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	return value
+}
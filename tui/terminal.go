@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/felixschmelzer/todoist-go"
+	"golang.org/x/term"
+)
+
+// RunTerminal runs a UI on the user's terminal. It switches the terminal to raw mode and the
+// alternate screen, and restores both when the user quits.
+func RunTerminal(ctx context.Context, client *todoist.TodoistClient) error {
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return errors.New("the terminal UI needs an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(in)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(in, state)
+
+	io.WriteString(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	defer io.WriteString(os.Stdout, "\x1b[?25h\x1b[?1049l")
+
+	ui := New(client, os.Stdin, os.Stdout, Options{
+		Size: func() (int, int) {
+			width, height, err := term.GetSize(out)
+			if err != nil {
+				return 80, 24
+			}
+			return width, height
+		},
+	})
+	return ui.Run(ctx)
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// buildTree arranges projects by their parents, each followed by its sections and subprojects.
+func buildTree(projects []todoist.Project, sections []todoist.Section) []node {
+	known := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		known[p.ID] = true
+	}
+
+	children := make(map[string][]todoist.Project)
+	for _, p := range projects {
+		parent := ""
+		if p.ParentID != nil && known[*p.ParentID] {
+			parent = *p.ParentID
+		}
+		children[parent] = append(children[parent], p)
+	}
+	for _, list := range children {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].IsInboxProject != list[j].IsInboxProject {
+				return list[i].IsInboxProject
+			}
+			return list[i].Order < list[j].Order
+		})
+	}
+
+	bySection := make(map[string][]todoist.Section)
+	for _, s := range sections {
+		bySection[s.ProjectID] = append(bySection[s.ProjectID], s)
+	}
+	for _, list := range bySection {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+	}
+
+	var nodes []node
+	var add func(parent string, depth int)
+	add = func(parent string, depth int) {
+		for _, p := range children[parent] {
+			nodes = append(nodes, node{project: p, depth: depth})
+			for i := range bySection[p.ID] {
+				nodes = append(nodes, node{project: p, section: &bySection[p.ID][i], depth: depth + 1})
+			}
+			add(p.ID, depth+1)
+		}
+	}
+	add("", 0)
+
+	return nodes
+}
+
+// taskRow is a row of the task list.
+type taskRow struct {
+	id    string
+	depth int
+}
+
+// taskRows returns the tasks of the selected project or section in display order,
+// grouped by section and with subtasks below their parents.
+func (u *UI) taskRows() []taskRow {
+	n, ok := u.selectedNode()
+	if !ok || n.project.ID != u.taskProjectID {
+		return nil
+	}
+
+	sectionOrder := make(map[string]int)
+	for _, s := range u.sections {
+		if s.ProjectID == n.project.ID {
+			sectionOrder[s.ID] = s.Order
+		}
+	}
+
+	var visible []*todoist.Task
+	inView := make(map[string]bool)
+	for i := range u.tasks {
+		t := &u.tasks[i]
+		if t.ProjectID != n.project.ID || (n.section != nil && t.SectionID != n.section.ID) {
+			continue
+		}
+		visible = append(visible, t)
+		inView[t.ID] = true
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		a, b := visible[i], visible[j]
+		if a.SectionID != b.SectionID {
+			if a.SectionID == "" || b.SectionID == "" {
+				return a.SectionID == ""
+			}
+			return sectionOrder[a.SectionID] < sectionOrder[b.SectionID]
+		}
+		return a.Order < b.Order
+	})
+
+	children := make(map[string][]*todoist.Task)
+	for _, t := range visible {
+		parent := ""
+		if inView[t.ParentID] {
+			parent = t.ParentID
+		}
+		children[parent] = append(children[parent], t)
+	}
+
+	var rows []taskRow
+	var add func(parent string, depth int)
+	add = func(parent string, depth int) {
+		for _, t := range children[parent] {
+			rows = append(rows, taskRow{id: t.ID, depth: depth})
+			add(t.ID, depth+1)
+		}
+	}
+	add("", 0)
+
+	return rows
+}
+
+// descendants returns the IDs of a task's subtasks, recursively.
+func (u *UI) descendants(id string) []string {
+	var ids []string
+	for _, t := range u.tasks {
+		if t.ParentID == id {
+			ids = append(ids, t.ID)
+			ids = append(ids, u.descendants(t.ID)...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,285 @@
+// Package tui is a keyboard-driven terminal interface for browsing and triaging Todoist tasks.
+//
+// Changes are applied to the screen immediately and sent to Todoist in the background;
+// if a request fails the change is rolled back and the error is shown in the status line.
+package tui
+
+import (
+	"context"
+	"io"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+// Options configures a UI.
+type Options struct {
+	// Size returns the terminal size in columns and rows. It is called before every redraw,
+	// so resizes are picked up. Defaults to 80x24.
+	Size func() (width, height int)
+}
+
+type focus int
+
+const (
+	focusTree focus = iota
+	focusTasks
+	focusComments
+)
+
+// node is a row of the project and section tree.
+type node struct {
+	project todoist.Project
+	section *todoist.Section
+	depth   int
+}
+
+// prompt is the inline editor shown in the status line.
+type prompt struct {
+	label  string
+	input  []rune
+	submit func(value string)
+}
+
+// UI is a terminal interface over a TodoistClient. It reads key presses from its input
+// and draws to its output using ANSI escape sequences.
+type UI struct {
+	client *todoist.TodoistClient
+	in     io.Reader
+	out    io.Writer
+	size   func() (int, int)
+
+	projects []todoist.Project
+	sections []todoist.Section
+	nodes    []node
+	nodeIdx  int
+
+	// tasks holds the active tasks of the loaded project, plus tasks completed in this session so they can be reopened.
+	tasks         []todoist.Task
+	taskProjectID string
+	taskIdx       int
+
+	comments      []todoist.Comment
+	commentTaskID string
+	commentIdx    int
+
+	focus     focus
+	prompt    *prompt
+	movingID  string // Task being moved while a target is picked in the tree
+	moveIdx   int    // Tree row picked as the move target
+	showHelp  bool
+	status    string
+	statusErr bool
+
+	posts    chan func()
+	done     chan struct{} // Closed when Run returns, so background requests finishing later do not block
+	loading  int           // Loads in flight; key presses wait until they are done
+	pending  int           // Changes in flight
+	loadGen  int
+	nextTemp int
+	quit     bool
+}
+
+// New creates a UI reading keys from in and drawing to out. Use RunTerminal to run it on the user's terminal.
+func New(client *todoist.TodoistClient, in io.Reader, out io.Writer, opts Options) *UI {
+	size := opts.Size
+	if size == nil {
+		size = func() (int, int) { return 80, 24 }
+	}
+	return &UI{
+		client: client,
+		in:     in,
+		out:    out,
+		size:   size,
+		posts:  make(chan func()),
+		done:   make(chan struct{}),
+	}
+}
+
+// Run loads the account and processes key presses until the user quits, the input ends or the context is cancelled.
+// Key presses are held back while data is loading, and Run waits for changes in flight before it returns,
+// so scripted input behaves the same on every run.
+func (u *UI) Run(ctx context.Context) error {
+	defer close(u.done)
+
+	keys := make(chan keyEvent)
+	go readKeys(u.in, keys, u.done)
+
+	u.reload()
+	for {
+		u.render()
+		if u.quit && u.pending == 0 {
+			return nil
+		}
+
+		var keyCh <-chan keyEvent
+		if u.loading == 0 && !u.quit {
+			keyCh = keys
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case fn := <-u.posts:
+			fn()
+		case k, ok := <-keyCh:
+			if !ok {
+				u.quit = true
+				continue
+			}
+			u.handleKey(k)
+		}
+	}
+}
+
+// load runs a read request in the background. Key presses are held back until it is done.
+func (u *UI) load(call func() error, done func(err error)) {
+	u.loading++
+	go func() {
+		err := call()
+		u.post(func() {
+			u.loading--
+			done(err)
+		})
+	}()
+}
+
+// change runs a write request in the background; done is called on the UI goroutine with its result.
+func (u *UI) change(call func() error, done func(err error)) {
+	u.pending++
+	go func() {
+		err := call()
+		u.post(func() {
+			u.pending--
+			done(err)
+		})
+	}()
+}
+
+// post hands fn to the UI goroutine, or drops it once Run has returned.
+func (u *UI) post(fn func()) {
+	select {
+	case u.posts <- fn:
+	case <-u.done:
+	}
+}
+
+func (u *UI) setStatus(format string, args ...interface{}) {
+	u.status = sprintf(format, args...)
+	u.statusErr = false
+}
+
+func (u *UI) setError(format string, args ...interface{}) {
+	u.status = sprintf(format, args...)
+	u.statusErr = true
+}
+
+// reload fetches projects and sections, then the tasks of the selected project.
+func (u *UI) reload() {
+	var projects []todoist.Project
+	var sections []todoist.Section
+
+	u.setStatus("Loading…")
+	u.load(func() error {
+		var err error
+		if projects, err = u.client.GetProjects(); err != nil {
+			return err
+		}
+		sections, err = u.client.GetSections("")
+		return err
+	}, func(err error) {
+		if err != nil {
+			u.setError("Failed to load projects: %v", err)
+			return
+		}
+		u.projects, u.sections = projects, sections
+		u.nodes = buildTree(projects, sections)
+		u.nodeIdx = clamp(u.nodeIdx, len(u.nodes))
+		u.taskProjectID = ""
+		u.setStatus("")
+		u.loadTasks()
+	})
+}
+
+// loadTasks fetches the tasks of the selected project unless they are already loaded.
+func (u *UI) loadTasks() {
+	n, ok := u.selectedNode()
+	if !ok || n.project.ID == u.taskProjectID {
+		return
+	}
+
+	u.loadGen++
+	gen := u.loadGen
+	projectID := n.project.ID
+
+	var tasks []todoist.Task
+	u.load(func() error {
+		var err error
+		tasks, err = u.client.GetTasks(projectID, "", "")
+		return err
+	}, func(err error) {
+		if gen != u.loadGen {
+			return
+		}
+		if err != nil {
+			u.setError("Failed to load tasks: %v", err)
+			return
+		}
+		u.tasks = tasks
+		u.taskProjectID = projectID
+		u.taskIdx = 0
+	})
+}
+
+// loadComments opens the comment view of a task.
+func (u *UI) loadComments(taskID string) {
+	var comments []todoist.Comment
+	u.load(func() error {
+		var err error
+		comments, err = u.client.GetComments(taskID, "")
+		return err
+	}, func(err error) {
+		if err != nil {
+			u.setError("Failed to load comments: %v", err)
+			return
+		}
+		u.comments = comments
+		u.commentTaskID = taskID
+		u.commentIdx = 0
+		u.focus = focusComments
+	})
+}
+
+func (u *UI) selectedNode() (node, bool) {
+	if u.nodeIdx < 0 || u.nodeIdx >= len(u.nodes) {
+		return node{}, false
+	}
+	return u.nodes[u.nodeIdx], true
+}
+
+// selectedTask returns the index in u.tasks of the selected task row.
+func (u *UI) selectedTask() (int, bool) {
+	rows := u.taskRows()
+	if u.taskIdx < 0 || u.taskIdx >= len(rows) {
+		return -1, false
+	}
+	return u.taskIndex(rows[u.taskIdx].id), true
+}
+
+func (u *UI) taskIndex(id string) int {
+	for i, t := range u.tasks {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func clamp(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
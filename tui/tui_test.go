@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/felixschmelzer/todoist-go"
+	"github.com/felixschmelzer/todoist-go/todoisttest"
+)
+
+// runScript runs a UI against server with the given key presses as input. The UI quits when the input ends.
+func runScript(t *testing.T, server *todoisttest.Server, keys string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	ui := New(server.Client(), strings.NewReader(keys), &out, Options{})
+	if err := ui.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	return out.String()
+}
+
+func newTask(t *testing.T, server *todoisttest.Server, params todoist.TaskParams) *todoist.Task {
+	t.Helper()
+
+	task, err := server.Client().CreateTask(params)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	return task
+}
+
+func serverTask(t *testing.T, server *todoisttest.Server, id string) todoist.Task {
+	t.Helper()
+
+	for _, task := range server.Tasks() {
+		if task.ID == id {
+			return task
+		}
+	}
+	t.Fatalf("task %s not found", id)
+	return todoist.Task{}
+}
+
+func TestRunShowsTasks(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+	newTask(t, server, todoist.TaskParams{Content: "Write report"})
+
+	out := runScript(t, server, "q")
+	if !strings.Contains(out, "Inbox") || !strings.Contains(out, "Write report") {
+		t.Errorf("screen does not show the inbox and its task:\n%q", out)
+	}
+}
+
+func TestCompleteTask(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+	task := newTask(t, server, todoist.TaskParams{Content: "Write report"})
+
+	runScript(t, server, "\tx")
+	if !serverTask(t, server, task.ID).IsCompleted {
+		t.Error("task was not completed")
+	}
+}
+
+func TestEditTask(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  string
+		check func(todoist.Task) bool
+	}{
+		{"content", "\te\x7f\x7f\x7f\x7f\x7f\x7fmemo\r", func(t todoist.Task) bool { return t.Content == "Write memo" }},
+		{"priority", "\tp\x7f\x7fP1\r", func(t todoist.Task) bool { return t.Priority == todoist.PriorityP1 }},
+		{"labels", "\tL, urgent\r", func(t todoist.Task) bool { return strings.Join(t.Labels, ",") == "home,urgent" }},
+		{"clear labels", "\tL\x7f\x7f\x7f\x7f\r", func(t todoist.Task) bool { return len(t.Labels) == 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := todoisttest.NewServer("token")
+			defer server.Close()
+			task := newTask(t, server, todoist.TaskParams{Content: "Write report", Labels: []string{"home"}})
+
+			runScript(t, server, tt.keys)
+			if got := serverTask(t, server, task.ID); !tt.check(got) {
+				t.Errorf("task after edit = %+v", got)
+			}
+		})
+	}
+}
+
+func TestAddTaskAndComment(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+
+	// The task is still being created until the first run ends, so it is commented on in a second one.
+	runScript(t, server, "aBuy milk\r")
+	runScript(t, server, "\tcaTwo liters\r")
+
+	tasks := server.Tasks()
+	if len(tasks) != 1 || tasks[0].Content != "Buy milk" {
+		t.Fatalf("tasks = %+v, want one task \"Buy milk\"", tasks)
+	}
+	comments := server.Comments()
+	if len(comments) != 1 || comments[0].TaskID != tasks[0].ID || comments[0].Content != "Two liters" {
+		t.Errorf("comments = %+v, want one comment on the new task", comments)
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+	project, err := server.Client().CreateProject(todoist.ProjectParams{Name: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := newTask(t, server, todoist.TaskParams{Content: "Write report"})
+
+	runScript(t, server, "\tmj\r")
+	if got := serverTask(t, server, task.ID).ProjectID; got != project.ID {
+		t.Errorf("task project = %s, want %s", got, project.ID)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	server := todoisttest.NewServer("token")
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	in, w := io.Pipe()
+	defer w.Close()
+	ui := New(server.Client(), in, io.Discard, Options{})
+	if err := ui.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v, want context.Canceled", err)
+	}
+
+	// Requests finishing after Run returned must not block on the UI goroutine.
+	ui.post(func() {})
+}
@@ -0,0 +1,146 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultMaxUploadSize is the upload size limit used when UploadParams.MaxSize is not set.
+const DefaultMaxUploadSize int64 = 100 << 20
+
+// ErrUploadTooLarge is returned when an upload exceeds its size limit.
+var ErrUploadTooLarge = errors.New("upload exceeds the maximum file size")
+
+// UploadParams defines the parameters for uploading a file to Todoist.
+type UploadParams struct {
+	File     io.Reader
+	FileName string
+	FileType string // MIME type, detected from FileName if empty
+	Size     int64  // Optional, used for early size checks and progress reporting
+	MaxSize  int64  // Defaults to DefaultMaxUploadSize
+	Progress func(sent, total int64)
+}
+
+// UploadFile uploads a file to Todoist and returns an attachment that can be passed to CreateComment.
+func (c *TodoistClient) UploadFile(params UploadParams) (*Attachment, error) {
+	return c.UploadFileContext(context.Background(), params)
+}
+
+// UploadFileContext is like UploadFile but uses ctx for the request.
+func (c *TodoistClient) UploadFileContext(ctx context.Context, params UploadParams) (*Attachment, error) {
+	if params.File == nil {
+		return nil, errors.New("upload file reader is nil")
+	}
+	if params.FileName == "" {
+		return nil, errors.New("upload file name is empty")
+	}
+
+	maxSize := params.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxUploadSize
+	}
+	if params.Size > maxSize {
+		return nil, fmt.Errorf("%w: %d bytes (limit %d)", ErrUploadTooLarge, params.Size, maxSize)
+	}
+
+	fileType := params.FileType
+	if fileType == "" {
+		fileType = mime.TypeByExtension(filepath.Ext(params.FileName))
+	}
+	if fileType == "" {
+		fileType = "application/octet-stream"
+	}
+
+	url := fmt.Sprintf("%s/uploads/add", c.SyncBaseURL)
+
+	// Stream the multipart body so large files are never held in memory.
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeUploadBody(mw, params, fileType, maxSize))
+	}()
+
+	resp, err := sendBody(ctx, c.HTTPClient, "POST", url, c.tokenSource(), mw.FormDataContentType(), pr)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachment Attachment
+	if err := parseResponse(resp, &attachment); err != nil {
+		return nil, err
+	}
+	if attachment.FileType == "" {
+		attachment.FileType = fileType
+	}
+
+	return &attachment, nil
+}
+
+// CreateCommentWithFile uploads a file and creates a comment with it attached in one call.
+func (c *TodoistClient) CreateCommentWithFile(params CommentParams, upload UploadParams) (*Comment, error) {
+	return c.CreateCommentWithFileContext(context.Background(), params, upload)
+}
+
+// CreateCommentWithFileContext is like CreateCommentWithFile but uses ctx for the requests.
+func (c *TodoistClient) CreateCommentWithFileContext(ctx context.Context, params CommentParams, upload UploadParams) (*Comment, error) {
+	attachment, err := c.UploadFileContext(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Attachment = attachment
+	return c.CreateCommentContext(ctx, params)
+}
+
+// writeUploadBody writes the multipart form for an upload, enforcing the size limit.
+func writeUploadBody(mw *multipart.Writer, params UploadParams, fileType string, maxSize int64) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(params.FileName)))
+	header.Set("Content-Type", fileType)
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	total := params.Size
+	if total <= 0 {
+		total = -1
+	}
+	src := &uploadReader{r: params.File, max: maxSize, total: total, progress: params.Progress}
+	if _, err := io.Copy(part, src); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// uploadReader counts the bytes read from an upload source and reports progress.
+type uploadReader struct {
+	r        io.Reader
+	sent     int64
+	max      int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	u.sent += int64(n)
+	if u.sent > u.max {
+		return n, fmt.Errorf("%w: more than %d bytes", ErrUploadTooLarge, u.max)
+	}
+	if n > 0 && u.progress != nil {
+		u.progress(u.sent, u.total)
+	}
+	return n, err
+}
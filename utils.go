@@ -2,15 +2,17 @@ package todoist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // sendRequest is a helper function to make an API call to Todoist.
-func sendRequest(client *http.Client, method, url, token string, body interface{}) (*http.Response, error) {
+func sendRequest(ctx context.Context, client *http.Client, method, url string, tokens TokenSource, body interface{}) (*http.Response, error) {
 	var requestBody io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -20,12 +22,25 @@ func sendRequest(client *http.Client, method, url, token string, body interface{
 		requestBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, requestBody)
+	return sendBody(ctx, client, method, url, tokens, "application/json", requestBody)
+}
+
+// sendBody is a helper function to make an API call with an already encoded request body.
+func sendBody(ctx context.Context, client *http.Client, method, url string, tokens TokenSource, contentType string, body io.Reader) (*http.Response, error) {
+	token, err := tokens.Token()
+	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -35,13 +50,17 @@ func sendRequest(client *http.Client, method, url, token string, body interface{
 	return resp, nil
 }
 
+// sendForm is a helper function to POST form encoded parameters, as expected by the Sync API.
+func sendForm(ctx context.Context, client *http.Client, endpoint string, tokens TokenSource, form url.Values) (*http.Response, error) {
+	return sendBody(ctx, client, "POST", endpoint, tokens, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+}
+
 // parseResponse is a helper function to parse the response body into a target struct.
 func parseResponse(resp *http.Response, target interface{}) error {
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return errors.New(fmt.Sprintf("API request failed with status code: %d", resp.StatusCode))
+		return newAPIError(resp, "")
 	}
+	defer resp.Body.Close()
 
 	if target != nil {
 		return json.NewDecoder(resp.Body).Decode(target)
@@ -0,0 +1,399 @@
+package todoist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError describes a single invalid field in request parameters.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors collects all invalid fields found while validating request parameters.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fieldErr := range e {
+		msgs[i] = fieldErr.Error()
+	}
+	return "invalid parameters: " + strings.Join(msgs, "; ")
+}
+
+// add records an invalid field.
+func (e *ValidationErrors) add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
+
+// err returns the collected errors, or nil if there are none.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// validateColor records an error if a non-empty color is not part of the Todoist palette.
+func (e *ValidationErrors) validateColor(color Color) {
+	if color != "" && !color.IsValid() {
+		e.add("color", fmt.Sprintf("unknown color %q", color))
+	}
+}
+
+// Validate checks the task parameters against the constraints documented by Todoist.
+func (p TaskParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to create a task.
+func (p TaskParams) ValidateCreate() error {
+	return p.validateNew().err()
+}
+
+// validateNew checks the fields a new task needs.
+func (p TaskParams) validateNew() ValidationErrors {
+	errs := p.validate()
+	if strings.TrimSpace(p.Content) == "" {
+		errs.add("content", "is required")
+	}
+	return errs
+}
+
+func (p TaskParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	if p.Priority != 0 && !p.Priority.IsValid() {
+		errs.add("priority", "must be between 1 and 4")
+	}
+
+	dueFields := 0
+	for _, v := range []string{p.DueString, p.DueDate, p.DueDatetime} {
+		if v != "" {
+			dueFields++
+		}
+	}
+	if dueFields > 1 {
+		errs.add("due", "only one of due_string, due_date and due_datetime may be set")
+	}
+	if p.DueDate != "" {
+		if _, err := time.Parse("2006-01-02", p.DueDate); err != nil {
+			errs.add("due_date", "must be formatted as YYYY-MM-DD")
+		}
+	}
+	if p.DueDatetime != "" {
+		if _, err := time.Parse(time.RFC3339, p.DueDatetime); err != nil {
+			errs.add("due_datetime", "must be an RFC 3339 date and time")
+		}
+	}
+	if p.DueLang != "" && p.DueString == "" {
+		errs.add("due_lang", "requires due_string")
+	}
+
+	switch {
+	case p.Duration < 0:
+		errs.add("duration", "must be positive")
+	case p.Duration > 0 && p.DurationUnit == "":
+		errs.add("duration_unit", "is required when duration is set")
+	case p.Duration == 0 && p.DurationUnit != "":
+		errs.add("duration", "is required when duration_unit is set")
+	}
+	if p.DurationUnit != "" && !p.DurationUnit.IsValid() {
+		errs.add("duration_unit", `must be "minute" or "day"`)
+	}
+
+	for _, label := range p.Labels {
+		if strings.TrimSpace(label) == "" {
+			errs.add("labels", "must not contain empty names")
+			break
+		}
+	}
+
+	return errs
+}
+
+// Validate checks that exactly one move target is set.
+func (p MoveTaskParams) Validate() error {
+	var errs ValidationErrors
+
+	targets := 0
+	for _, v := range []string{p.ProjectID, p.SectionID, p.ParentID} {
+		if v != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		errs.add("target", "exactly one of project_id, section_id and parent_id must be set")
+	}
+
+	return errs.err()
+}
+
+// Validate checks the page size and time range of a completed tasks request.
+func (p CompletedTasksParams) Validate() error {
+	var errs ValidationErrors
+
+	if p.Limit < 0 || p.Limit > MaxCompletedTasksLimit {
+		errs.add("limit", fmt.Sprintf("must be between 0 and %d (0 for the default)", MaxCompletedTasksLimit))
+	}
+	if p.Offset < 0 {
+		errs.add("offset", "must not be negative")
+	}
+	if !p.Since.IsZero() && !p.Until.IsZero() && p.Until.Before(p.Since) {
+		errs.add("until", "must not be before since")
+	}
+
+	return errs.err()
+}
+
+// Validate checks the project parameters against the constraints documented by Todoist.
+func (p ProjectParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to create a project.
+func (p ProjectParams) ValidateCreate() error {
+	errs := p.validate()
+	if strings.TrimSpace(p.Name) == "" {
+		errs.add("name", "is required")
+	}
+	return errs.err()
+}
+
+func (p ProjectParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	errs.validateColor(p.Color)
+	if p.ViewStyle != "" && p.ViewStyle != ViewStyleList && p.ViewStyle != ViewStyleBoard {
+		errs.add("view_style", `must be "list" or "board"`)
+	}
+
+	return errs
+}
+
+// Validate checks the section parameters against the constraints documented by Todoist.
+func (p SectionParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to create a section.
+func (p SectionParams) ValidateCreate() error {
+	errs := p.validate()
+	if p.ProjectID == "" {
+		errs.add("project_id", "is required")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		errs.add("name", "is required")
+	}
+	return errs.err()
+}
+
+func (p SectionParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	if p.Order < 0 {
+		errs.add("order", "must not be negative")
+	}
+
+	return errs
+}
+
+// Validate checks the comment parameters against the constraints documented by Todoist.
+func (p CommentParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to create a comment.
+func (p CommentParams) ValidateCreate() error {
+	errs := p.validate()
+	if p.TaskID == "" && p.ProjectID == "" {
+		errs.add("task_id", "either task_id or project_id is required")
+	}
+	if strings.TrimSpace(p.Content) == "" && p.Attachment == nil {
+		errs.add("content", "is required unless an attachment is given")
+	}
+	return errs.err()
+}
+
+func (p CommentParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	if p.TaskID != "" && p.ProjectID != "" {
+		errs.add("task_id", "task_id and project_id are mutually exclusive")
+	}
+	if p.Attachment != nil && p.Attachment.FileURL == "" {
+		errs.add("attachment", "file_url is required")
+	}
+
+	return errs
+}
+
+// Validate checks the label parameters against the constraints documented by Todoist.
+func (p LabelParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to create a label.
+func (p LabelParams) ValidateCreate() error {
+	errs := p.validate()
+	if strings.TrimSpace(p.Name) == "" {
+		errs.add("name", "is required")
+	}
+	return errs.err()
+}
+
+func (p LabelParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	errs.validateColor(p.Color)
+	if p.Order < 0 {
+		errs.add("order", "must not be negative")
+	}
+
+	return errs
+}
+
+// Validate checks the filter parameters against the constraints documented by Todoist.
+func (p FilterParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to create a filter.
+func (p FilterParams) ValidateCreate() error {
+	errs := p.validate()
+	if strings.TrimSpace(p.Name) == "" {
+		errs.add("name", "is required")
+	}
+	if strings.TrimSpace(p.Query) == "" {
+		errs.add("query", "is required")
+	}
+	return errs.err()
+}
+
+func (p FilterParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	errs.validateColor(p.Color)
+	if p.Order != nil && *p.Order < 0 {
+		errs.add("item_order", "must not be negative")
+	}
+
+	return errs
+}
+
+// Validate checks the shared label parameters against the constraints documented by Todoist.
+func (p SharedLabelParams) Validate() error {
+	return p.validate().err()
+}
+
+// validateRename additionally checks the fields required to rename a shared label.
+func (p SharedLabelParams) validateRename() error {
+	errs := p.validate()
+	if strings.TrimSpace(p.NewName) == "" {
+		errs.add("new_name", "is required")
+	}
+	return errs.err()
+}
+
+func (p SharedLabelParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	if strings.TrimSpace(p.Name) == "" {
+		errs.add("name", "is required")
+	}
+
+	return errs
+}
+
+// Validate checks the reminder parameters against the constraints documented by Todoist.
+func (p ReminderParams) Validate() error {
+	return p.validate().err()
+}
+
+// ValidateCreate is like Validate but also checks the fields required to add a reminder.
+func (p ReminderParams) ValidateCreate() error {
+	errs := p.validateNew()
+	if strings.TrimSpace(p.TaskID) == "" {
+		errs.add("item_id", "is required")
+	}
+	return errs.err()
+}
+
+// validateNew checks the fields a new reminder needs, apart from its task.
+func (p ReminderParams) validateNew() ValidationErrors {
+	errs := p.validate()
+
+	switch p.Type {
+	case "":
+		errs.add("type", "is required")
+	case ReminderAbsolute:
+		if p.DueDatetime == "" {
+			errs.add("due_datetime", "is required for absolute reminders")
+		}
+	case ReminderLocation:
+		if strings.TrimSpace(p.Name) == "" {
+			errs.add("name", "is required for location reminders")
+		}
+		if p.Latitude == "" || p.Longitude == "" {
+			errs.add("loc_lat", "latitude and longitude are required for location reminders")
+		}
+		if p.Trigger == "" {
+			errs.add("loc_trigger", "is required for location reminders")
+		}
+	}
+
+	return errs
+}
+
+func (p ReminderParams) validate() ValidationErrors {
+	var errs ValidationErrors
+
+	if p.Type != "" && !p.Type.IsValid() {
+		errs.add("type", `must be "relative", "absolute" or "location"`)
+	}
+	if p.MinuteOffset != nil && *p.MinuteOffset < 0 {
+		errs.add("minute_offset", "must not be negative")
+	}
+	if p.DueDatetime != "" {
+		if _, err := time.Parse(time.RFC3339, p.DueDatetime); err != nil {
+			errs.add("due_datetime", "must be an RFC 3339 date and time")
+		}
+	}
+	if p.Latitude != "" {
+		if lat, err := strconv.ParseFloat(p.Latitude, 64); err != nil || lat < -90 || lat > 90 {
+			errs.add("loc_lat", "must be a number between -90 and 90")
+		}
+	}
+	if p.Longitude != "" {
+		if long, err := strconv.ParseFloat(p.Longitude, 64); err != nil || long < -180 || long > 180 {
+			errs.add("loc_long", "must be a number between -180 and 180")
+		}
+	}
+	if p.Trigger != "" && p.Trigger != TriggerOnEnter && p.Trigger != TriggerOnLeave {
+		errs.add("loc_trigger", `must be "on_enter" or "on_leave"`)
+	}
+	if p.Radius < 0 {
+		errs.add("radius", "must not be negative")
+	}
+
+	// Fields of other reminder types are rejected rather than silently dropped.
+	location := p.Name != "" || p.Latitude != "" || p.Longitude != "" || p.Trigger != "" || p.Radius != 0
+	if p.Type.IsValid() && p.Type != ReminderRelative && p.MinuteOffset != nil {
+		errs.add("minute_offset", "only applies to relative reminders")
+	}
+	if p.Type.IsValid() && p.Type != ReminderAbsolute && p.DueDatetime != "" {
+		errs.add("due_datetime", "only applies to absolute reminders")
+	}
+	if p.Type.IsValid() && p.Type != ReminderLocation && location {
+		errs.add("type", "location fields only apply to location reminders")
+	}
+
+	return errs
+}
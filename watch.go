@@ -0,0 +1,472 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of change reported by a Watcher.
+type EventType string
+
+const (
+	EventProjectAdded      EventType = "project_added"
+	EventProjectUpdated    EventType = "project_updated"
+	EventProjectArchived   EventType = "project_archived"
+	EventProjectUnarchived EventType = "project_unarchived"
+	EventProjectDeleted    EventType = "project_deleted"
+	EventSectionAdded      EventType = "section_added"
+	EventSectionUpdated    EventType = "section_updated"
+	EventSectionArchived   EventType = "section_archived"
+	EventSectionUnarchived EventType = "section_unarchived"
+	EventSectionDeleted    EventType = "section_deleted"
+	EventTaskAdded         EventType = "task_added"
+	EventTaskUpdated       EventType = "task_updated"
+	EventTaskCompleted     EventType = "task_completed"
+	EventTaskUncompleted   EventType = "task_uncompleted"
+	EventTaskDeleted       EventType = "task_deleted"
+	EventCommentAdded      EventType = "comment_added"
+	EventCommentUpdated    EventType = "comment_updated"
+	EventCommentDeleted    EventType = "comment_deleted"
+	EventLabelAdded        EventType = "label_added"
+	EventLabelUpdated      EventType = "label_updated"
+	EventLabelDeleted      EventType = "label_deleted"
+)
+
+// DefaultWatchInterval is the polling interval used when WatcherOptions.Interval is not set.
+const DefaultWatchInterval = 30 * time.Second
+
+// DefaultCompletedRetention is how long completed tasks are remembered when
+// WatcherOptions.CompletedRetention is not set.
+const DefaultCompletedRetention = 30 * 24 * time.Hour
+
+// ErrWatcherStarted is returned by Run when it was called before. Run closes the Events channel when
+// it returns, so a stopped watcher cannot be restarted; create a new one from its State instead.
+var ErrWatcherStarted = errors.New("watcher has already been run")
+
+// FieldChange describes a changed field of an updated record, named after its JSON field.
+type FieldChange struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// WatchEvent is a change detected by a Watcher. Only the field matching the event type is set.
+// For deletions it holds the last known state of the record.
+type WatchEvent struct {
+	Type    EventType
+	Project *Project
+	Section *Section
+	Task    *Task
+	Comment *Comment
+	Label   *Label
+	Changes []FieldChange // Set for update events
+}
+
+// WatchState is the state of a Watcher, which can be persisted to resume watching without missing changes.
+type WatchState struct {
+	SyncToken string                 `json:"sync_token"`
+	Projects  map[string]SyncProject `json:"projects"`
+	Sections  map[string]SyncSection `json:"sections"`
+	Items     map[string]SyncItem    `json:"items"` // Active tasks
+	Notes     map[string]SyncNote    `json:"notes"`
+	Labels    map[string]SyncLabel   `json:"labels"`
+	// Completed maps the IDs of tasks seen being completed to when they were last seen. Only their IDs
+	// are kept, to report them as uncompleted when they are reopened within the retention time.
+	Completed map[string]time.Time `json:"completed,omitempty"`
+}
+
+// WatcherOptions configures a Watcher.
+type WatcherOptions struct {
+	Interval    time.Duration
+	State       *WatchState      // Resume from a previously saved state
+	EmitInitial bool             // Report everything found by the first sync as added
+	OnEvent     func(WatchEvent) // Receives events instead of the Events channel when set
+	OnError     func(error)      // Receives errors of polls made by Run; they are dropped otherwise
+	// CompletedRetention is how long completed tasks are remembered. A task reopened later is
+	// reported as added instead of uncompleted. Defaults to DefaultCompletedRetention.
+	CompletedRetention time.Duration
+}
+
+// Watcher reports changes to an account by syncing it incrementally at a fixed interval.
+type Watcher struct {
+	client *TodoistClient
+	opts   WatcherOptions
+	events chan WatchEvent
+
+	pollMu  sync.Mutex // Serializes polls
+	mu      sync.Mutex // Guards state and started, but is not held during requests
+	state   WatchState
+	started bool
+}
+
+// watchResources are the resource types a Watcher syncs.
+var watchResources = []ResourceType{
+	ResourceProjects, ResourceSections, ResourceItems, ResourceNotes, ResourceProjectNotes, ResourceLabels,
+}
+
+// NewWatcher creates a watcher for the account of the client.
+func NewWatcher(client *TodoistClient, opts WatcherOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if opts.CompletedRetention <= 0 {
+		opts.CompletedRetention = DefaultCompletedRetention
+	}
+
+	w := &Watcher{
+		client: client,
+		opts:   opts,
+		events: make(chan WatchEvent, 64),
+	}
+	if opts.State != nil {
+		w.state = copyWatchState(*opts.State)
+	} else {
+		w.state = copyWatchState(WatchState{})
+	}
+	return w
+}
+
+// Events returns the channel events are delivered on when no OnEvent callback is set.
+// It is closed when Run returns.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// State returns a copy of the current state, suitable for WatcherOptions.State.
+func (w *Watcher) State() WatchState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return copyWatchState(w.state)
+}
+
+// Run polls for changes until the context is cancelled. It can only be called once.
+func (w *Watcher) Run(ctx context.Context) error {
+	w.mu.Lock()
+	started := w.started
+	w.started = true
+	w.mu.Unlock()
+	if started {
+		return ErrWatcherStarted
+	}
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.PollContext(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+		for _, event := range events {
+			if w.opts.OnEvent != nil {
+				w.opts.OnEvent(event)
+				continue
+			}
+			select {
+			case w.events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll syncs once and returns the changes since the previous poll.
+// The first poll without a resumed state only records a baseline unless EmitInitial is set.
+func (w *Watcher) Poll() ([]WatchEvent, error) {
+	return w.PollContext(context.Background())
+}
+
+// PollContext is like Poll but uses ctx for the sync request.
+func (w *Watcher) PollContext(ctx context.Context) ([]WatchEvent, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	w.mu.Lock()
+	token := w.state.SyncToken
+	w.mu.Unlock()
+
+	initial := token == ""
+	resp, err := w.client.SyncContext(ctx, token, watchResources...)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var events []WatchEvent
+	emit := func(e WatchEvent) {
+		if !initial || w.opts.EmitInitial {
+			events = append(events, e)
+		}
+	}
+
+	diffRecords(w.state.Projects, resp.Projects, resp.FullSync,
+		func(p SyncProject) (string, bool) { return p.ID, p.IsDeleted },
+		func(old, cur *SyncProject) { emitProjectEvents(old, cur, emit) })
+	diffRecords(w.state.Sections, resp.Sections, resp.FullSync,
+		func(s SyncSection) (string, bool) { return s.ID, s.IsDeleted },
+		func(old, cur *SyncSection) { emitSectionEvents(old, cur, emit) })
+	now := time.Now()
+	diffItems(&w.state, resp.Items, resp.FullSync, now, emit)
+	for id, completedAt := range w.state.Completed {
+		if now.Sub(completedAt) > w.opts.CompletedRetention {
+			delete(w.state.Completed, id)
+		}
+	}
+	diffRecords(w.state.Notes, append(resp.Notes, resp.ProjectNotes...), resp.FullSync,
+		func(n SyncNote) (string, bool) { return n.ID, n.IsDeleted },
+		func(old, cur *SyncNote) { emitCommentEvents(old, cur, emit) })
+	diffRecords(w.state.Labels, resp.Labels, resp.FullSync,
+		func(l SyncLabel) (string, bool) { return l.ID, l.IsDeleted },
+		func(old, cur *SyncLabel) { emitLabelEvents(old, cur, emit) })
+
+	w.state.SyncToken = resp.SyncToken
+	return events, nil
+}
+
+// diffRecords applies synced changes to a snapshot and reports each difference through changed,
+// with a nil old record for additions and a nil cur record for deletions.
+func diffRecords[T any](snapshot map[string]T, changes []T, fullSync bool, key func(T) (string, bool), changed func(old, cur *T)) {
+	seen := make(map[string]bool, len(changes))
+
+	for _, record := range changes {
+		id, deleted := key(record)
+		seen[id] = true
+
+		old, existed := snapshot[id]
+		switch {
+		case deleted && existed:
+			delete(snapshot, id)
+			changed(&old, nil)
+		case deleted:
+		case existed:
+			snapshot[id] = record
+			changed(&old, &record)
+		default:
+			snapshot[id] = record
+			changed(nil, &record)
+		}
+	}
+
+	// A full sync lists everything that exists, so records missing from it were deleted.
+	if fullSync {
+		for id, old := range snapshot {
+			if !seen[id] {
+				delete(snapshot, id)
+				changed(&old, nil)
+			}
+		}
+	}
+}
+
+// diffItems is diffRecords for tasks. Completed tasks are dropped from the snapshot, apart from
+// their IDs and the time now. A full sync leaves them out, so they are not reported as deleted then.
+func diffItems(state *WatchState, changes []SyncItem, fullSync bool, now time.Time, emit func(WatchEvent)) {
+	diffRecords(state.Items, changes, fullSync,
+		func(i SyncItem) (string, bool) { return i.ID, i.IsDeleted },
+		func(old, cur *SyncItem) {
+			var completed bool
+			if cur != nil {
+				_, completed = state.Completed[cur.ID]
+			}
+			switch {
+			case old == nil && completed:
+				if !cur.Checked {
+					delete(state.Completed, cur.ID)
+					task := cur.ToTask()
+					emit(WatchEvent{Type: EventTaskUncompleted, Task: &task})
+				}
+			default:
+				emitTaskEvents(old, cur, emit)
+			}
+			if cur != nil && cur.Checked {
+				delete(state.Items, cur.ID)
+				state.Completed[cur.ID] = now
+			}
+		})
+
+	for _, item := range changes {
+		if _, ok := state.Completed[item.ID]; ok && item.IsDeleted {
+			delete(state.Completed, item.ID)
+			task := item.ToTask()
+			emit(WatchEvent{Type: EventTaskDeleted, Task: &task})
+		}
+	}
+}
+
+func emitProjectEvents(old, cur *SyncProject, emit func(WatchEvent)) {
+	switch {
+	case cur == nil:
+		project := old.ToProject()
+		emit(WatchEvent{Type: EventProjectDeleted, Project: &project})
+		return
+	case old == nil:
+		project := cur.ToProject()
+		emit(WatchEvent{Type: EventProjectAdded, Project: &project})
+		return
+	}
+
+	project := cur.ToProject()
+	if !old.IsArchived && cur.IsArchived {
+		emit(WatchEvent{Type: EventProjectArchived, Project: &project})
+	} else if old.IsArchived && !cur.IsArchived {
+		emit(WatchEvent{Type: EventProjectUnarchived, Project: &project})
+	}
+	if changes := diffFields(old.ToProject(), project); len(changes) > 0 {
+		emit(WatchEvent{Type: EventProjectUpdated, Project: &project, Changes: changes})
+	}
+}
+
+func emitSectionEvents(old, cur *SyncSection, emit func(WatchEvent)) {
+	switch {
+	case cur == nil:
+		section := old.ToSection()
+		emit(WatchEvent{Type: EventSectionDeleted, Section: &section})
+		return
+	case old == nil:
+		section := cur.ToSection()
+		emit(WatchEvent{Type: EventSectionAdded, Section: &section})
+		return
+	}
+
+	section := cur.ToSection()
+	if !old.IsArchived && cur.IsArchived {
+		emit(WatchEvent{Type: EventSectionArchived, Section: &section})
+	} else if old.IsArchived && !cur.IsArchived {
+		emit(WatchEvent{Type: EventSectionUnarchived, Section: &section})
+	}
+	if changes := diffFields(old.ToSection(), section); len(changes) > 0 {
+		emit(WatchEvent{Type: EventSectionUpdated, Section: &section, Changes: changes})
+	}
+}
+
+func emitTaskEvents(old, cur *SyncItem, emit func(WatchEvent)) {
+	switch {
+	case cur == nil:
+		task := old.ToTask()
+		emit(WatchEvent{Type: EventTaskDeleted, Task: &task})
+		return
+	case old == nil:
+		// A task added and completed between two polls is reported as both.
+		task := cur.ToTask()
+		emit(WatchEvent{Type: EventTaskAdded, Task: &task})
+		if cur.Checked {
+			emit(WatchEvent{Type: EventTaskCompleted, Task: &task})
+		}
+		return
+	}
+
+	task := cur.ToTask()
+	if !old.Checked && cur.Checked {
+		emit(WatchEvent{Type: EventTaskCompleted, Task: &task})
+	} else if old.Checked && !cur.Checked {
+		emit(WatchEvent{Type: EventTaskUncompleted, Task: &task})
+	}
+	if changes := diffFields(old.ToTask(), task, "is_completed"); len(changes) > 0 {
+		emit(WatchEvent{Type: EventTaskUpdated, Task: &task, Changes: changes})
+	}
+}
+
+func emitCommentEvents(old, cur *SyncNote, emit func(WatchEvent)) {
+	switch {
+	case cur == nil:
+		comment := old.ToComment()
+		emit(WatchEvent{Type: EventCommentDeleted, Comment: &comment})
+	case old == nil:
+		comment := cur.ToComment()
+		emit(WatchEvent{Type: EventCommentAdded, Comment: &comment})
+	default:
+		comment := cur.ToComment()
+		if changes := diffFields(old.ToComment(), comment); len(changes) > 0 {
+			emit(WatchEvent{Type: EventCommentUpdated, Comment: &comment, Changes: changes})
+		}
+	}
+}
+
+func emitLabelEvents(old, cur *SyncLabel, emit func(WatchEvent)) {
+	switch {
+	case cur == nil:
+		label := old.ToLabel()
+		emit(WatchEvent{Type: EventLabelDeleted, Label: &label})
+	case old == nil:
+		label := cur.ToLabel()
+		emit(WatchEvent{Type: EventLabelAdded, Label: &label})
+	default:
+		label := cur.ToLabel()
+		if changes := diffFields(old.ToLabel(), label); len(changes) > 0 {
+			emit(WatchEvent{Type: EventLabelUpdated, Label: &label, Changes: changes})
+		}
+	}
+}
+
+// diffFields compares two records by their JSON fields and returns the changed ones, sorted by name.
+func diffFields(old, cur interface{}, ignore ...string) []FieldChange {
+	oldFields, curFields := jsonFields(old), jsonFields(cur)
+	for _, field := range ignore {
+		delete(oldFields, field)
+		delete(curFields, field)
+	}
+
+	var changes []FieldChange
+	for field, oldValue := range oldFields {
+		if curValue := curFields[field]; !reflect.DeepEqual(oldValue, curValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: curValue})
+		}
+	}
+	for field, curValue := range curFields {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, New: curValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// jsonFields returns the JSON object fields of a record.
+func jsonFields(v interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+	return fields
+}
+
+// copyWatchState deep copies the snapshot maps of a state, allocating missing ones.
+func copyWatchState(s WatchState) WatchState {
+	return WatchState{
+		SyncToken: s.SyncToken,
+		Projects:  copyMap(s.Projects),
+		Sections:  copyMap(s.Sections),
+		Items:     copyMap(s.Items),
+		Notes:     copyMap(s.Notes),
+		Labels:    copyMap(s.Labels),
+		Completed: copyMap(s.Completed),
+	}
+}
+
+func copyMap[T any](m map[string]T) map[string]T {
+	c := make(map[string]T, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,331 @@
+package todoist
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Webhook event names sent by Todoist.
+const (
+	WebhookItemAdded         = "item:added"
+	WebhookItemUpdated       = "item:updated"
+	WebhookItemDeleted       = "item:deleted"
+	WebhookItemCompleted     = "item:completed"
+	WebhookItemUncompleted   = "item:uncompleted"
+	WebhookNoteAdded         = "note:added"
+	WebhookNoteUpdated       = "note:updated"
+	WebhookNoteDeleted       = "note:deleted"
+	WebhookProjectAdded      = "project:added"
+	WebhookProjectUpdated    = "project:updated"
+	WebhookProjectDeleted    = "project:deleted"
+	WebhookProjectArchived   = "project:archived"
+	WebhookProjectUnarchived = "project:unarchived"
+	WebhookSectionAdded      = "section:added"
+	WebhookSectionUpdated    = "section:updated"
+	WebhookSectionDeleted    = "section:deleted"
+	WebhookSectionArchived   = "section:archived"
+	WebhookSectionUnarchived = "section:unarchived"
+	WebhookLabelAdded        = "label:added"
+	WebhookLabelDeleted      = "label:deleted"
+	WebhookLabelUpdated      = "label:updated"
+)
+
+const (
+	webhookSignatureHeader  = "X-Todoist-Hmac-SHA256"
+	webhookDeliveryIDHeader = "X-Todoist-Delivery-ID"
+
+	// maxWebhookBodySize limits the size of accepted webhook requests.
+	maxWebhookBodySize = 1 << 20
+	// defaultDeliveryTTL is how long delivery IDs are remembered to detect duplicates.
+	defaultDeliveryTTL = 24 * time.Hour
+	// defaultWebhookMaxAge covers the retries of Todoist, well within defaultDeliveryTTL so that
+	// replays of older events are rejected rather than missed by the duplicate check.
+	defaultWebhookMaxAge = time.Hour
+)
+
+// WebhookEvent is a webhook request sent by Todoist.
+type WebhookEvent struct {
+	EventName      string           `json:"event_name"`
+	UserID         string           `json:"user_id"`
+	EventData      json.RawMessage  `json:"event_data"`
+	EventDataExtra json.RawMessage  `json:"event_data_extra,omitempty"`
+	Initiator      WebhookInitiator `json:"initiator"`
+	Version        string           `json:"version"`
+	TriggeredAt    string           `json:"triggered_at"`
+	DeliveryID     string           `json:"-"`
+}
+
+// WebhookInitiator is the user who caused a webhook event.
+type WebhookInitiator struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FullName  string `json:"full_name"`
+	ImageID   string `json:"image_id"`
+	IsPremium bool   `json:"is_premium"`
+}
+
+// Typed handler functions for webhook events. Returning an error makes Todoist deliver the event again.
+type (
+	WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+	TaskHandlerFunc    func(ctx context.Context, event *WebhookEvent, task *Task) error
+	CommentHandlerFunc func(ctx context.Context, event *WebhookEvent, comment *Comment) error
+	ProjectHandlerFunc func(ctx context.Context, event *WebhookEvent, project *Project) error
+	SectionHandlerFunc func(ctx context.Context, event *WebhookEvent, section *Section) error
+	LabelHandlerFunc   func(ctx context.Context, event *WebhookEvent, label *Label) error
+)
+
+// WebhookHandler is an http.Handler that verifies Todoist webhook requests and dispatches them to handler funcs.
+type WebhookHandler struct {
+	// MaxAge rejects events triggered longer ago than this. NewWebhookHandler sets it to one hour;
+	// zero disables the check.
+	MaxAge time.Duration
+
+	secret     []byte
+	deliveries *deliveryLog
+
+	mu       sync.RWMutex
+	tasks    map[string]TaskHandlerFunc
+	comments map[string]CommentHandlerFunc
+	projects map[string]ProjectHandlerFunc
+	sections map[string]SectionHandlerFunc
+	labels   map[string]LabelHandlerFunc
+	fallback WebhookHandlerFunc
+}
+
+// NewWebhookHandler creates a webhook handler that verifies requests with the client secret of the Todoist app.
+func NewWebhookHandler(clientSecret string) *WebhookHandler {
+	return &WebhookHandler{
+		MaxAge:     defaultWebhookMaxAge,
+		secret:     []byte(clientSecret),
+		deliveries: newDeliveryLog(defaultDeliveryTTL),
+		tasks:      make(map[string]TaskHandlerFunc),
+		comments:   make(map[string]CommentHandlerFunc),
+		projects:   make(map[string]ProjectHandlerFunc),
+		sections:   make(map[string]SectionHandlerFunc),
+		labels:     make(map[string]LabelHandlerFunc),
+	}
+}
+
+// OnTask registers a handler for an "item:*" event.
+func (h *WebhookHandler) OnTask(eventName string, fn TaskHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tasks[eventName] = fn
+}
+
+// OnComment registers a handler for a "note:*" event.
+func (h *WebhookHandler) OnComment(eventName string, fn CommentHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.comments[eventName] = fn
+}
+
+// OnProject registers a handler for a "project:*" event.
+func (h *WebhookHandler) OnProject(eventName string, fn ProjectHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.projects[eventName] = fn
+}
+
+// OnSection registers a handler for a "section:*" event.
+func (h *WebhookHandler) OnSection(eventName string, fn SectionHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sections[eventName] = fn
+}
+
+// OnLabel registers a handler for a "label:*" event.
+func (h *WebhookHandler) OnLabel(eventName string, fn LabelHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.labels[eventName] = fn
+}
+
+// OnOther registers a handler for all events without a typed handler, such as "reminder:fired".
+func (h *WebhookHandler) OnOther(fn WebhookHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+// VerifyWebhookSignature reports whether signature is the valid base64 HMAC-SHA256 of body for the client secret.
+func VerifyWebhookSignature(clientSecret string, body []byte, signature string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ServeHTTP verifies and dispatches a webhook request.
+// Duplicate deliveries are acknowledged without being dispatched again. They are recognized by
+// their delivery ID, or by their body for requests without one.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxWebhookBodySize {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !VerifyWebhookSignature(string(h.secret), body, r.Header.Get(webhookSignatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	event.DeliveryID = r.Header.Get(webhookDeliveryIDHeader)
+
+	if h.MaxAge > 0 {
+		triggered, err := time.Parse(time.RFC3339, event.TriggeredAt)
+		if err != nil || time.Since(triggered) > h.MaxAge {
+			http.Error(w, "event too old", http.StatusBadRequest)
+			return
+		}
+	}
+
+	delivery := event.DeliveryID
+	if delivery == "" {
+		delivery = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+	if !h.deliveries.claim(delivery) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), &event); err != nil {
+		// Forget the delivery so the retry sent by Todoist is processed.
+		h.deliveries.release(delivery)
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch decodes the event data and calls the handler registered for the event.
+func (h *WebhookHandler) dispatch(ctx context.Context, event *WebhookEvent) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	resource, _, _ := strings.Cut(event.EventName, ":")
+	switch resource {
+	case "item":
+		if fn, ok := h.tasks[event.EventName]; ok {
+			var item SyncItem
+			if err := json.Unmarshal(event.EventData, &item); err != nil {
+				return fmt.Errorf("invalid %s event data: %w", event.EventName, err)
+			}
+			task := item.ToTask()
+			return fn(ctx, event, &task)
+		}
+	case "note":
+		if fn, ok := h.comments[event.EventName]; ok {
+			var note SyncNote
+			if err := json.Unmarshal(event.EventData, &note); err != nil {
+				return fmt.Errorf("invalid %s event data: %w", event.EventName, err)
+			}
+			comment := note.ToComment()
+			return fn(ctx, event, &comment)
+		}
+	case "project":
+		if fn, ok := h.projects[event.EventName]; ok {
+			var p SyncProject
+			if err := json.Unmarshal(event.EventData, &p); err != nil {
+				return fmt.Errorf("invalid %s event data: %w", event.EventName, err)
+			}
+			project := p.ToProject()
+			return fn(ctx, event, &project)
+		}
+	case "section":
+		if fn, ok := h.sections[event.EventName]; ok {
+			var s SyncSection
+			if err := json.Unmarshal(event.EventData, &s); err != nil {
+				return fmt.Errorf("invalid %s event data: %w", event.EventName, err)
+			}
+			section := s.ToSection()
+			return fn(ctx, event, &section)
+		}
+	case "label":
+		if fn, ok := h.labels[event.EventName]; ok {
+			var l SyncLabel
+			if err := json.Unmarshal(event.EventData, &l); err != nil {
+				return fmt.Errorf("invalid %s event data: %w", event.EventName, err)
+			}
+			label := l.ToLabel()
+			return fn(ctx, event, &label)
+		}
+	}
+
+	if h.fallback != nil {
+		return h.fallback(ctx, event)
+	}
+	return nil
+}
+
+// deliveryLog remembers recent delivery IDs to detect duplicates and replays. Expired IDs are
+// swept at most once per 1/24 of the TTL, so a claim does not scan the whole log every time.
+type deliveryLog struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	seen  map[string]time.Time
+	swept time.Time
+}
+
+func newDeliveryLog(ttl time.Duration) *deliveryLog {
+	return &deliveryLog{ttl: ttl, seen: make(map[string]time.Time), swept: time.Now()}
+}
+
+// claim records a delivery ID and reports whether it was new.
+func (d *deliveryLog) claim(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(d.swept) >= d.ttl/24 {
+		for seenID, at := range d.seen {
+			if now.Sub(at) > d.ttl {
+				delete(d.seen, seenID)
+			}
+		}
+		d.swept = now
+	}
+
+	if at, ok := d.seen[id]; ok && now.Sub(at) <= d.ttl {
+		return false
+	}
+	d.seen[id] = now
+	return true
+}
+
+// release forgets a delivery ID so it can be processed again.
+func (d *deliveryLog) release(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, id)
+}
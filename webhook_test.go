@@ -0,0 +1,130 @@
+package todoist_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixschmelzer/todoist-go"
+)
+
+const webhookSecret = "secret"
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBody(content string, triggered time.Time) string {
+	return fmt.Sprintf(`{"event_name":"item:added","user_id":"1","event_data":{"id":"7","content":%q},"triggered_at":%q}`,
+		content, triggered.UTC().Format(time.RFC3339))
+}
+
+// delivery is a webhook request; an empty signature is computed from the body.
+type delivery struct {
+	body, signature, id string
+}
+
+func (d delivery) send(h http.Handler) int {
+	signature := d.signature
+	if signature == "" {
+		signature = sign(webhookSecret, d.body)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(d.body))
+	req.Header.Set("X-Todoist-Hmac-SHA256", signature)
+	if d.id != "" {
+		req.Header.Set("X-Todoist-Delivery-ID", d.id)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhookHandler(t *testing.T) {
+	now := time.Now()
+	fresh := webhookBody("Buy milk", now)
+	other := webhookBody("Call mom", now)
+
+	tests := []struct {
+		name       string
+		deliveries []delivery
+		wantCodes  []int
+		wantCalls  int
+	}{
+		{"valid", []delivery{{body: fresh}}, []int{200}, 1},
+		{"bad signature", []delivery{{body: fresh, signature: sign("wrong", fresh)}}, []int{401}, 0},
+		{"signature of another body", []delivery{{body: fresh, signature: sign(webhookSecret, other)}}, []int{401}, 0},
+		{"malformed signature", []delivery{{body: fresh, signature: "not base64!"}}, []int{401}, 0},
+		{"stale triggered_at", []delivery{{body: webhookBody("Buy milk", now.Add(-2*time.Hour))}}, []int{400}, 0},
+		{"missing triggered_at", []delivery{{body: `{"event_name":"item:added","event_data":{"id":"7"}}`}}, []int{400}, 0},
+		{"duplicate delivery ID", []delivery{{body: fresh, id: "d1"}, {body: fresh, id: "d1"}}, []int{200, 200}, 1},
+		{"distinct delivery IDs", []delivery{{body: fresh, id: "d1"}, {body: fresh, id: "d2"}}, []int{200, 200}, 2},
+		{"duplicate body without delivery ID", []delivery{{body: fresh}, {body: fresh}}, []int{200, 200}, 1},
+		{"different bodies without delivery ID", []delivery{{body: fresh}, {body: other}}, []int{200, 200}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := todoist.NewWebhookHandler(webhookSecret)
+			calls := 0
+			h.OnTask(todoist.WebhookItemAdded, func(ctx context.Context, event *todoist.WebhookEvent, task *todoist.Task) error {
+				calls++
+				return nil
+			})
+
+			for i, d := range tt.deliveries {
+				if code := d.send(h); code != tt.wantCodes[i] {
+					t.Errorf("delivery %d: status %d, want %d", i, code, tt.wantCodes[i])
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerRetry(t *testing.T) {
+	h := todoist.NewWebhookHandler(webhookSecret)
+	var contents []string
+	fail := true
+	h.OnTask(todoist.WebhookItemAdded, func(ctx context.Context, event *todoist.WebhookEvent, task *todoist.Task) error {
+		contents = append(contents, task.Content)
+		if fail {
+			fail = false
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	// A failed delivery is forgotten, so the retry of Todoist is processed.
+	d := delivery{body: webhookBody("Buy milk", time.Now()), id: "d1"}
+	if code := d.send(h); code != http.StatusInternalServerError {
+		t.Fatalf("failing delivery: status %d, want 500", code)
+	}
+	if code := d.send(h); code != http.StatusOK {
+		t.Fatalf("retry: status %d, want 200", code)
+	}
+	if len(contents) != 2 || contents[1] != "Buy milk" {
+		t.Errorf("handled %q, want the delivery twice", contents)
+	}
+}
+
+func TestWebhookHandlerMaxAgeDisabled(t *testing.T) {
+	h := todoist.NewWebhookHandler(webhookSecret)
+	h.MaxAge = 0
+
+	d := delivery{body: webhookBody("Buy milk", time.Now().Add(-48*time.Hour))}
+	if code := d.send(h); code != http.StatusOK {
+		t.Errorf("status %d, want 200", code)
+	}
+}